@@ -6,8 +6,10 @@
 package logx
 
 import (
+	"context"
 	"io"
 	"log/slog"
+	"math"
 	"os"
 	"sync/atomic"
 )
@@ -16,14 +18,13 @@ type adapterSlog struct {
 	level   atomic.Uint32
 	handler func(w io.Writer) slog.Handler
 	log     *slog.Logger
+	writer  io.Writer
 }
 
 func NewSLogJsonAdapter() Logger {
 	obj := &adapterSlog{
 		handler: func(w io.Writer) slog.Handler {
-			return slog.NewJSONHandler(w, &slog.HandlerOptions{
-				Level: slog.LevelDebug,
-			})
+			return slog.NewJSONHandler(w, slogHandlerOptions())
 		},
 	}
 	obj.level.Store(LevelDebug)
@@ -34,9 +35,7 @@ func NewSLogJsonAdapter() Logger {
 func NewSLogStringAdapter() Logger {
 	obj := &adapterSlog{
 		handler: func(w io.Writer) slog.Handler {
-			return slog.NewTextHandler(w, &slog.HandlerOptions{
-				Level: slog.LevelDebug,
-			})
+			return slog.NewTextHandler(w, slogHandlerOptions())
 		},
 	}
 	obj.level.Store(LevelDebug)
@@ -44,10 +43,61 @@ func NewSLogStringAdapter() Logger {
 	return obj
 }
 
+// slogHandlerOptions disables filtering in slog, the adapter checks levels itself,
+// and renders names of the logx levels that have no slog equivalent.
+func slogHandlerOptions() *slog.HandlerOptions {
+	return &slog.HandlerOptions{
+		Level: slog.Level(math.MinInt),
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) > 0 || a.Key != slog.LevelKey {
+				return a
+			}
+			if l, ok := a.Value.Any().(slog.Level); ok {
+				if name, ok := slogLevelName(l); ok {
+					a.Value = slog.StringValue(name)
+				}
+			}
+			return a
+		},
+	}
+}
+
+// slogLevel converts logx level to slog level: INFO=0, WARN=4, ERROR=8, DEBUG=-4,
+// other levels are placed proportionally between them.
+func slogLevel(level uint32) slog.Level {
+	return slog.Level((levelRank(LevelInfo) - levelRank(level)) * 4 / levelRankStep)
+}
+
+func slogLevelName(l slog.Level) (string, bool) {
+	switch l {
+	case slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError:
+		return "", false
+	}
+	for value, info := range *levels.Load() {
+		if slogLevel(value) == l {
+			return info.name, true
+		}
+	}
+	return "", false
+}
+
 func (v *adapterSlog) SetOutput(out io.Writer) {
+	v.writer = out
 	v.log = slog.New(v.handler(out))
 }
 
+func (v *adapterSlog) Sync() error {
+	return syncWriter(v.writer)
+}
+
+func (v *adapterSlog) Close() error {
+	return closeWriter(v.writer)
+}
+
+func (v *adapterSlog) Shutdown(ctx context.Context) error {
+	return shutdown(ctx, v.Close)
+}
+
 func (v *adapterSlog) SetFormatter(_ Formatter) {}
 
 func (v *adapterSlog) SetLevel(l uint32) {
@@ -55,34 +105,51 @@ func (v *adapterSlog) SetLevel(l uint32) {
 }
 
 func (v *adapterSlog) Fatal(message string, args ...interface{}) {
-	v.log.Error(message, args...)
+	v.log.Log(context.Background(), slogLevel(LevelFatal), message, args...)
+	v.Close() //nolint:errcheck
 	os.Exit(1)
 }
 
+func (v *adapterSlog) Panic(message string, args ...interface{}) {
+	v.log.Log(context.Background(), slogLevel(LevelPanic), message, args...)
+	panic(message)
+}
+
 func (v *adapterSlog) Error(message string, args ...interface{}) {
-	if v.level.Load() < LevelError {
+	if !levelEnabled(LevelError, v.level.Load()) {
 		return
 	}
 	v.log.Error(message, args...)
 }
 
 func (v *adapterSlog) Warn(message string, args ...interface{}) {
-	if v.level.Load() < LevelWarn {
+	if !levelEnabled(LevelWarn, v.level.Load()) {
 		return
 	}
 	v.log.Warn(message, args...)
 }
 
 func (v *adapterSlog) Info(message string, args ...interface{}) {
-	if v.level.Load() < LevelInfo {
+	if !levelEnabled(LevelInfo, v.level.Load()) {
 		return
 	}
 	v.log.Info(message, args...)
 }
 
 func (v *adapterSlog) Debug(message string, args ...interface{}) {
-	if v.level.Load() < LevelDebug {
+	if !levelEnabled(LevelDebug, v.level.Load()) {
 		return
 	}
 	v.log.Debug(message, args...)
 }
+
+func (v *adapterSlog) Trace(message string, args ...interface{}) {
+	v.Log(LevelTrace, message, args...)
+}
+
+func (v *adapterSlog) Log(level uint32, message string, args ...interface{}) {
+	if !levelEnabled(level, v.level.Load()) {
+		return
+	}
+	v.log.Log(context.Background(), slogLevel(level), message, args...)
+}
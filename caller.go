@@ -0,0 +1,39 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"runtime"
+	"strings"
+)
+
+// skipPackages frames of logx and of the standard log package redirected to logx
+var skipPackages = []string{"go.osspkg.com/logx.", "log."}
+
+// findCaller first frame outside of the logx package
+func findCaller() Caller {
+	var pcs [16]uintptr
+	n := runtime.Callers(3, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !skipFrame(frame.Function) {
+			return Caller{File: frame.File, Line: frame.Line, Func: frame.Function}
+		}
+		if !more {
+			return Caller{}
+		}
+	}
+}
+
+func skipFrame(name string) bool {
+	for _, prefix := range skipPackages {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
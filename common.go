@@ -7,22 +7,19 @@ package logx
 
 import "io"
 
+// Built-in levels. Messages are written when the level is enabled for the logger,
+// the order of the levels comes from the level registry, not from the values:
+// FATAL, PANIC, ERROR, WARN, INFO, DEBUG, TRACE. Custom levels are placed with RegisterLevel.
 const (
-	levelFatal uint32 = iota
+	LevelFatal uint32 = iota
 	LevelError
 	LevelWarn
 	LevelInfo
 	LevelDebug
+	LevelTrace
+	LevelPanic
 )
 
-var levels = map[uint32]string{
-	levelFatal: "FATAL",
-	LevelError: "ERROR",
-	LevelWarn:  "WARN",
-	LevelInfo:  "INFO",
-	LevelDebug: "DEBUG",
-}
-
 // Logger base interface
 type Logger interface {
 	SetOutput(out io.Writer)
@@ -34,4 +31,11 @@ type Logger interface {
 	Warn(message string, args ...interface{})
 	Info(message string, args ...interface{})
 	Debug(message string, args ...interface{})
+	Trace(message string, args ...interface{})
+	Log(level uint32, message string, args ...interface{})
+}
+
+// Panicker optional interface of loggers writing a message before panic, see Panic
+type Panicker interface {
+	Panic(message string, args ...interface{})
 }
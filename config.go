@@ -0,0 +1,369 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	OutputStdout = "stdout"
+	OutputStderr = "stderr"
+	OutputFile   = "file"
+	OutputSyslog = "syslog"
+)
+
+const (
+	FormatNameJSON    = "json"
+	FormatNameString  = "string"
+	FormatNameGELF    = "gelf"
+	FormatNameECS     = "ecs"
+	FormatNameOTel    = "otel"
+	FormatNameGCP     = "gcp"
+	FormatNameAWS     = "aws"
+	FormatNameCBOR    = "cbor"
+	FormatNameMsgpack = "msgpack"
+)
+
+// Config declarative logger configuration, can be decoded from YAML or JSON
+//
+//	level: info
+//	format: json
+//	caller: true
+//	loggers:
+//	  db.*: debug
+//	fields:
+//	  service: api
+//	outputs:
+//	  - type: stdout
+//	  - type: file
+//	    path: /var/log/api.log
+//	    format: string
+//	    rotation: {max_size: 100, max_backups: 5, max_age: 168h}
+//	  - type: syslog
+//	    network: udp
+//	    address: 127.0.0.1:514
+//	    facility: local0
+//	sampling: {tick: 1s, initial: 100, thereafter: 10}
+type Config struct {
+	Level    string            `json:"level,omitempty" yaml:"level,omitempty"`
+	Format   string            `json:"format,omitempty" yaml:"format,omitempty"`
+	Caller   bool              `json:"caller,omitempty" yaml:"caller,omitempty"`
+	Loggers  map[string]string `json:"loggers,omitempty" yaml:"loggers,omitempty"`
+	Fields   map[string]string `json:"fields,omitempty" yaml:"fields,omitempty"`
+	Outputs  []OutputConfig    `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+	Sampling *SamplingConfig   `json:"sampling,omitempty" yaml:"sampling,omitempty"`
+}
+
+// OutputConfig one target of the log records
+type OutputConfig struct {
+	// Type stdout, stderr, file or syslog
+	Type string `json:"type" yaml:"type"`
+	// Format overrides Config.Format for this output, rfc5424 (default) or rfc3164 for syslog
+	Format string `json:"format,omitempty" yaml:"format,omitempty"`
+
+	// Path of the file output
+	Path     string          `json:"path,omitempty" yaml:"path,omitempty"`
+	Rotation *RotationConfig `json:"rotation,omitempty" yaml:"rotation,omitempty"`
+
+	// Network (unix, unixgram, udp, tcp, tls) and Address of the syslog output, empty for the local syslog
+	Network  string `json:"network,omitempty" yaml:"network,omitempty"`
+	Address  string `json:"address,omitempty" yaml:"address,omitempty"`
+	Facility string `json:"facility,omitempty" yaml:"facility,omitempty"`
+	// Tag app name of the syslog records
+	Tag string `json:"tag,omitempty" yaml:"tag,omitempty"`
+}
+
+// RotationConfig of the file output, zero values disable the limits
+type RotationConfig struct {
+	// MaxSize in megabytes
+	MaxSize    int64  `json:"max_size,omitempty" yaml:"max_size,omitempty"`
+	MaxBackups int    `json:"max_backups,omitempty" yaml:"max_backups,omitempty"`
+	MaxAge     string `json:"max_age,omitempty" yaml:"max_age,omitempty"`
+}
+
+// SamplingConfig of repeated messages, see Sampler
+type SamplingConfig struct {
+	Tick       string `json:"tick,omitempty" yaml:"tick,omitempty"`
+	Initial    uint64 `json:"initial" yaml:"initial"`
+	Thereafter uint64 `json:"thereafter" yaml:"thereafter"`
+}
+
+// LoadEnv override config from environment:
+//
+//	LOGX_LEVEL=debug
+//	LOGX_FORMAT=string
+//	LOGX_OUTPUT=stdout,/var/log/app.log
+//
+// LOGX_OUTPUT is a comma separated list of stdout, stderr or file paths,
+// it replaces all outputs of the config.
+func (c *Config) LoadEnv() error {
+	if v, ok := os.LookupEnv("LOGX_LEVEL"); ok {
+		c.Level = v
+	}
+	if v, ok := os.LookupEnv("LOGX_FORMAT"); ok {
+		c.Format = v
+	}
+	if v, ok := os.LookupEnv("LOGX_OUTPUT"); ok {
+		c.Outputs = nil
+		for _, item := range strings.Split(v, ",") {
+			item = strings.TrimSpace(item)
+			switch item {
+			case "":
+			case OutputStdout, OutputStderr:
+				c.Outputs = append(c.Outputs, OutputConfig{Type: item})
+			default:
+				c.Outputs = append(c.Outputs, OutputConfig{
+					Type: OutputFile,
+					Path: strings.TrimPrefix(item, OutputFile+":"),
+				})
+			}
+		}
+	}
+	return c.Validate()
+}
+
+// Validate config without opening outputs
+func (c *Config) Validate() error {
+	var errs []error
+	if len(c.Level) > 0 {
+		if _, err := ParseLevel(c.Level); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for pattern, level := range c.Loggers {
+		if _, err := ParseLevel(level); err != nil {
+			errs = append(errs, fmt.Errorf("logger %q: %w", pattern, err))
+		}
+	}
+	if _, err := formatterByName(c.Format); err != nil {
+		errs = append(errs, err)
+	}
+	for i, out := range c.Outputs {
+		if err := out.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("output #%d: %w", i, err))
+		}
+	}
+	if c.Sampling != nil && len(c.Sampling.Tick) > 0 {
+		if _, err := time.ParseDuration(c.Sampling.Tick); err != nil {
+			errs = append(errs, fmt.Errorf("sampling tick: %w", err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("logx config: %w", err)
+	}
+	return nil
+}
+
+func (c *OutputConfig) validate() error {
+	if c.Type == OutputSyslog {
+		return c.validateSyslog()
+	}
+	if len(c.Format) > 0 {
+		if _, err := formatterByName(c.Format); err != nil {
+			return err
+		}
+	}
+	switch c.Type {
+	case OutputStdout, OutputStderr:
+	case OutputFile:
+		if len(c.Path) == 0 {
+			return fmt.Errorf("empty file path")
+		}
+		if c.Rotation != nil && len(c.Rotation.MaxAge) > 0 {
+			if _, err := time.ParseDuration(c.Rotation.MaxAge); err != nil {
+				return fmt.Errorf("rotation max age: %w", err)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown output type %q", c.Type)
+	}
+	return nil
+}
+
+func (c *OutputConfig) validateSyslog() error {
+	switch strings.ToLower(c.Format) {
+	case "", SyslogRFC5424, SyslogRFC3164:
+	default:
+		return fmt.Errorf("unknown syslog format %q", c.Format)
+	}
+	switch c.Network {
+	case "", SyslogNetworkUnix, SyslogNetworkUnixgram:
+	case SyslogNetworkUDP, SyslogNetworkTCP, SyslogNetworkTLS:
+		if len(c.Address) == 0 {
+			return fmt.Errorf("empty syslog address")
+		}
+	default:
+		return fmt.Errorf("unknown syslog network %q", c.Network)
+	}
+	_, err := ParseSyslogFacility(c.Facility)
+	return err
+}
+
+func (c *OutputConfig) syslogOptions() SyslogOptions {
+	return SyslogOptions{
+		Network:  c.Network,
+		Address:  c.Address,
+		Format:   c.Format,
+		Facility: c.Facility,
+		AppName:  c.Tag,
+	}
+}
+
+// Build validate config and create logger with all outputs opened
+func (c *Config) Build() (Logger, error) {
+	opts, closeAll, err := c.options()
+	if err != nil {
+		return nil, err
+	}
+	l := New()
+	if err = l.Reconfigure(opts...); err != nil {
+		closeAll()
+		return nil, err
+	}
+	return l, nil
+}
+
+// Options validate config, open outputs and convert config to logger options.
+// Opened outputs are closed by Log.Close of the logger the options are applied to.
+func (c *Config) Options() ([]Option, error) {
+	opts, _, err := c.options()
+	return opts, err
+}
+
+func (c *Config) options() ([]Option, func(), error) {
+	if err := c.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	level := LevelError
+	if len(c.Level) > 0 {
+		level = MustParseLevel(c.Level).Uint32()
+	}
+	loggers := make(map[string]uint32, len(c.Loggers))
+	for pattern, l := range c.Loggers {
+		loggers[pattern] = MustParseLevel(l).Uint32()
+	}
+	fields := make([]interface{}, 0, len(c.Fields)*2)
+	for _, k := range sortedKeys(c.Fields) {
+		fields = append(fields, k, c.Fields[k])
+	}
+
+	outputs := c.Outputs
+	if len(outputs) == 0 {
+		outputs = []OutputConfig{{Type: OutputStdout}}
+	}
+	sinks := make([]*writerSink, 0, len(outputs))
+	closeAll := func() {
+		for _, s := range sinks {
+			s.Close() //nolint:errcheck
+		}
+	}
+	for _, out := range outputs {
+		s, err := out.open(c.Format)
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("logx config: %w", err)
+		}
+		sinks = append(sinks, s)
+	}
+	extra := make([]Sink, 0, len(sinks)-1)
+	for _, s := range sinks[1:] {
+		extra = append(extra, s)
+	}
+
+	var sampler *Sampler
+	if c.Sampling != nil {
+		tick, _ := time.ParseDuration(c.Sampling.Tick)
+		sampler = NewSampler(tick, c.Sampling.Initial, c.Sampling.Thereafter)
+	}
+
+	return []Option{
+		WithLevel(level),
+		WithNamedLevels(loggers),
+		WithOutput(sinks[0].writer),
+		WithFormatter(sinks[0].formatter),
+		WithSinks(extra...),
+		WithCaller(c.Caller),
+		WithFields(fields...),
+		WithSampler(sampler),
+	}, closeAll, nil
+}
+
+func (c *OutputConfig) open(format string) (*writerSink, error) {
+	if c.Type == OutputSyslog {
+		return newSyslogSink(c.syslogOptions())
+	}
+	if len(c.Format) > 0 {
+		format = c.Format
+	}
+	f, err := formatterByName(format)
+	if err != nil {
+		return nil, err
+	}
+
+	var w io.Writer
+	switch c.Type {
+	case OutputStdout:
+		w = os.Stdout
+	case OutputStderr:
+		w = os.Stderr
+	case OutputFile:
+		opts := FileOptions{}
+		if c.Rotation != nil {
+			opts.MaxSize = c.Rotation.MaxSize * 1024 * 1024
+			opts.MaxBackups = c.Rotation.MaxBackups
+			opts.MaxAge, _ = time.ParseDuration(c.Rotation.MaxAge)
+		}
+		if w, err = NewFileWriter(c.Path, opts); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown output type %q", c.Type)
+	}
+	return &writerSink{writer: w, formatter: f}, nil
+}
+
+// formatterByName json (default), string, gelf, ecs, otel, gcp, aws, cbor or msgpack
+func formatterByName(name string) (Formatter, error) {
+	switch strings.ToLower(name) {
+	case "", FormatNameJSON:
+		return NewFormatJSON(), nil
+	case FormatNameString, "text":
+		return NewFormatString(), nil
+	case FormatNameGELF:
+		return NewFormatGELF(), nil
+	case FormatNameECS:
+		return NewFormatECS(), nil
+	case FormatNameOTel:
+		return NewFormatOTel(), nil
+	case FormatNameGCP:
+		return NewFormatCloud(CloudGCP), nil
+	case FormatNameAWS, "cloudwatch":
+		return NewFormatCloud(CloudAWS), nil
+	case FormatNameCBOR:
+		return NewFormatCBOR(), nil
+	case FormatNameMsgpack:
+		return NewFormatMsgpack(), nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", name)
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
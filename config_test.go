@@ -0,0 +1,97 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx_test
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/logx"
+)
+
+func TestUnit_ConfigBuild(t *testing.T) {
+	dir := t.TempDir()
+	jsonFile, stringFile := filepath.Join(dir, "app.json"), filepath.Join(dir, "app.log")
+
+	var conf logx.Config
+	casecheck.NoError(t, json.Unmarshal([]byte(`{
+		"level": "info",
+		"loggers": {"db.*": "debug"},
+		"fields": {"service": "api"},
+		"outputs": [
+			{"type": "file", "path": "`+jsonFile+`"},
+			{"type": "file", "path": "`+stringFile+`", "format": "string", "rotation": {"max_size": 10}}
+		],
+		"sampling": {"tick": "1m", "initial": 2, "thereafter": 0}
+	}`), &conf))
+
+	l, err := conf.Build()
+	casecheck.NoError(t, err)
+
+	l.Debug("hidden")
+	l.(*logx.Log).Named("db").Debug("query")
+	for i := 0; i < 5; i++ {
+		l.Info("sampled")
+	}
+	casecheck.NoError(t, l.(io.Closer).Close())
+
+	b, err := os.ReadFile(jsonFile)
+	casecheck.NoError(t, err)
+	data := string(b)
+	casecheck.False(t, strings.Contains(data, "hidden"))
+	casecheck.Contains(t, data, `"level":"DEBUG","msg":"query","ctx":{`)
+	casecheck.Contains(t, data, `"service":"api"`)
+	casecheck.Equal(t, 2, strings.Count(data, "sampled"))
+
+	b, err = os.ReadFile(stringFile)
+	casecheck.NoError(t, err)
+	casecheck.Contains(t, string(b), "\"level\"=\"DEBUG\"\t\"msg\"=\"query\"\t\"logger\"=\"db\"\t\"service\"=\"api\"")
+}
+
+func TestUnit_ConfigValidate(t *testing.T) {
+	for _, conf := range []logx.Config{
+		{Level: "loud"},
+		{Format: "xml"},
+		{Loggers: map[string]string{"db": "loud"}},
+		{Outputs: []logx.OutputConfig{{Type: "kafka"}}},
+		{Outputs: []logx.OutputConfig{{Type: logx.OutputFile}}},
+		{Outputs: []logx.OutputConfig{{Type: logx.OutputStdout, Format: "xml"}}},
+		{Sampling: &logx.SamplingConfig{Tick: "soon"}},
+	} {
+		_, err := conf.Build()
+		casecheck.Error(t, err)
+	}
+}
+
+func TestUnit_ConfigLoadEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "env.log")
+	t.Setenv("LOGX_LEVEL", "debug")
+	t.Setenv("LOGX_FORMAT", "string")
+	t.Setenv("LOGX_OUTPUT", "file:"+path)
+
+	conf := logx.Config{Level: "error", Outputs: []logx.OutputConfig{{Type: logx.OutputStdout}}}
+	casecheck.NoError(t, conf.LoadEnv())
+	casecheck.Equal(t, "debug", conf.Level)
+	casecheck.Equal(t, []logx.OutputConfig{{Type: logx.OutputFile, Path: path}}, conf.Outputs)
+
+	l, err := conf.Build()
+	casecheck.NoError(t, err)
+	l.Debug("env")
+	casecheck.NoError(t, l.(io.Closer).Close())
+
+	b, err := os.ReadFile(path)
+	casecheck.NoError(t, err)
+	casecheck.Contains(t, string(b), "\"level\"=\"DEBUG\"\t\"msg\"=\"env\"")
+
+	t.Setenv("LOGX_LEVEL", "loud")
+	casecheck.Error(t, conf.LoadEnv())
+}
@@ -0,0 +1,23 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import "context"
+
+type ctxLoggerKey struct{}
+
+// ContextWithLogger returns a copy of ctx with the logger
+func ContextWithLogger(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, ctxLoggerKey{}, l)
+}
+
+// FromContext returns the logger of ctx or the default logger
+func FromContext(ctx context.Context) Logger {
+	if l, ok := ctx.Value(ctxLoggerKey{}).(Logger); ok && l != nil {
+		return l
+	}
+	return Default()
+}
@@ -0,0 +1,71 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Decoder reads records written by a formatter back into messages.
+// Decode returns io.EOF at the end of the stream, a malformed record returns an error
+// and the next call continues with the following record.
+// Ctx keeps the order of the fields, values the formatter writes as text are decoded as strings.
+type Decoder interface {
+	Decode() (*Message, error)
+}
+
+var (
+	_ Decoder = (*JSONDecoder)(nil)
+	_ Decoder = (*StringDecoder)(nil)
+	_ Decoder = (*CBORDecoder)(nil)
+	_ Decoder = (*MsgpackDecoder)(nil)
+)
+
+// NewDecoder decoder of the format by name: json (default), string, cbor or msgpack
+func NewDecoder(format string, r io.Reader) (Decoder, error) {
+	switch strings.ToLower(format) {
+	case "", FormatNameJSON:
+		return NewJSONDecoder(r), nil
+	case FormatNameString, "text":
+		return NewStringDecoder(r), nil
+	case FormatNameCBOR:
+		return NewCBORDecoder(r), nil
+	case FormatNameMsgpack:
+		return NewMsgpackDecoder(r), nil
+	default:
+		return nil, fmt.Errorf("logx decoder: unsupported format %q", format)
+	}
+}
+
+// lineReader non-empty lines of the text formats with their numbers for errors
+type lineReader struct {
+	r    *bufio.Reader
+	line int
+}
+
+func newLineReader(r io.Reader) lineReader {
+	return lineReader{r: bufio.NewReader(r)}
+}
+
+func (v *lineReader) next() ([]byte, error) {
+	for {
+		b, err := v.r.ReadBytes('\n')
+		if len(b) == 0 && err != nil {
+			return nil, err
+		}
+		v.line++
+		if b = bytes.TrimRight(b, "\r\n"); len(b) > 0 {
+			return b, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
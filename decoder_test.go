@@ -0,0 +1,134 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/logx"
+)
+
+func TestUnit_JSONDecoder(t *testing.T) {
+	ts := time.Date(2026, 1, 2, 3, 4, 5, 6000, time.UTC)
+	buf := &bytes.Buffer{}
+	f := logx.NewFormatJSON()
+	casecheck.NoError(t, f.Encode(buf, &logx.Message{
+		Time: ts, Level: "INFO", Message: "hello\nworld",
+		Caller: logx.Caller{File: "/src/app/main.go", Line: 42},
+		Ctx:    []interface{}{"user", "bob"},
+		Map:    map[string]string{},
+	}))
+	buf.WriteString("\nnot json\n")
+	buf.WriteString(`{"time":"2026-01-02T03:04:05Z","level":"WARN","msg":"m","ctx":{"z":"1","a":"2","m":"3"},"extra":12}` + "\n")
+	buf.WriteString(`{"level":"ERROR","msg":"last"}`)
+
+	dec := f.NewDecoder(buf)
+	m, err := dec.Decode()
+	casecheck.NoError(t, err)
+	casecheck.True(t, ts.Equal(m.Time))
+	casecheck.Equal(t, "INFO", m.Level)
+	casecheck.Equal(t, "hello\nworld", m.Message)
+	casecheck.Equal(t, logx.Caller{File: "app/main.go", Line: 42}, m.Caller)
+	casecheck.Equal(t, []interface{}{"user", "bob"}, m.Ctx)
+
+	_, err = dec.Decode()
+	casecheck.Error(t, err)
+	casecheck.Contains(t, err.Error(), "logx json decode: line 3")
+
+	m, err = dec.Decode()
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, "WARN", m.Level)
+	casecheck.Equal(t, []interface{}{"z", "1", "a", "2", "m", "3", "extra", int64(12)}, m.Ctx)
+
+	m, err = dec.Decode()
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, "last", m.Message)
+
+	_, err = dec.Decode()
+	casecheck.True(t, errors.Is(err, io.EOF))
+}
+
+func TestUnit_JSONDecoder_Malformed(t *testing.T) {
+	for _, line := range []string{
+		`[1,2]`,
+		`{"time":"yesterday"}`,
+		`{"ctx":["a"]}`,
+		`{"caller":"nowhere"}`,
+		`{"msg":"a"} {"msg":"b"}`,
+		`{"msg":"a"`,
+	} {
+		_, err := logx.NewJSONDecoder(strings.NewReader(line)).Decode()
+		casecheck.Error(t, err, line)
+	}
+}
+
+func TestUnit_StringDecoder(t *testing.T) {
+	ts := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, delim := range []byte{'\t', ' ', ';'} {
+		f := logx.NewFormatString()
+		f.SetDelimiter(delim)
+
+		buf := &bytes.Buffer{}
+		casecheck.NoError(t, f.Encode(buf, &logx.Message{
+			Time: ts, Level: "INFO", Message: "hello \"world\"",
+			Caller: logx.Caller{File: "/src/app/main.go", Line: 42},
+			Ctx:    []interface{}{"z", "a b;c\td", "a", 7, "err", errors.New("multi\nline"), "odd"},
+		}))
+		buf.WriteString("\"broken\"=\"value\n")
+		casecheck.NoError(t, f.Encode(buf, &logx.Message{Time: ts, Level: "WARN", Message: "next"}))
+
+		dec := f.NewDecoder(buf)
+		m, err := dec.Decode()
+		casecheck.NoError(t, err)
+		casecheck.True(t, ts.Equal(m.Time))
+		casecheck.Equal(t, "INFO", m.Level)
+		casecheck.Equal(t, "hello \"world\"", m.Message)
+		casecheck.Equal(t, logx.Caller{File: "app/main.go", Line: 42}, m.Caller)
+		casecheck.Equal(t, []interface{}{"z", "a b;c\td", "a", "7", "err", "multi\nline", "odd", "null"}, m.Ctx)
+
+		_, err = dec.Decode()
+		casecheck.Error(t, err)
+		casecheck.Contains(t, err.Error(), "logx string decode: line 2")
+
+		m, err = dec.Decode()
+		casecheck.NoError(t, err)
+		casecheck.Equal(t, "next", m.Message)
+
+		_, err = dec.Decode()
+		casecheck.True(t, errors.Is(err, io.EOF))
+	}
+}
+
+func TestUnit_StringDecoder_Malformed(t *testing.T) {
+	for _, line := range []string{
+		`level="INFO"`,
+		`"level"`,
+		`"level"="INFO""msg"="m"`,
+		`"time"="now"`,
+		`"caller"="main.go"`,
+	} {
+		_, err := logx.NewStringDecoder(strings.NewReader(line)).Decode()
+		casecheck.Error(t, err, line)
+	}
+}
+
+func TestUnit_NewDecoder(t *testing.T) {
+	for _, name := range []string{"", "json", "string", "text", "cbor", "msgpack"} {
+		dec, err := logx.NewDecoder(name, strings.NewReader(""))
+		casecheck.NoError(t, err)
+		_, err = dec.Decode()
+		casecheck.True(t, errors.Is(err, io.EOF))
+	}
+	_, err := logx.NewDecoder("gelf", strings.NewReader(""))
+	casecheck.Error(t, err)
+}
@@ -6,6 +6,7 @@
 package logx
 
 import (
+	"context"
 	"io"
 )
 
@@ -54,7 +55,52 @@ func Debug(format string, args ...interface{}) {
 	std.Debug(format, args...)
 }
 
+// Trace message
+func Trace(format string, args ...interface{}) {
+	std.Trace(format, args...)
+}
+
+// Panic message and panic, loggers without Panicker write the message with LevelPanic
+func Panic(format string, args ...interface{}) {
+	if p, ok := std.(Panicker); ok {
+		p.Panic(format, args...)
+		return
+	}
+	std.Log(LevelPanic, format, args...)
+	panic(format)
+}
+
 // Fatal message and exit
 func Fatal(format string, args ...interface{}) {
 	std.Fatal(format, args...)
 }
+
+// Sync flush buffered output of the default logger, if it implements Syncer
+func Sync() error {
+	if v, ok := std.(Syncer); ok {
+		return v.Sync()
+	}
+	return nil
+}
+
+// Close the default logger, if it implements io.Closer
+func Close() error {
+	if v, ok := std.(io.Closer); ok {
+		return v.Close()
+	}
+	return nil
+}
+
+// Shutdown the default logger waiting no longer than ctx allows.
+// Loggers without Shutdowner are synced and closed if they support it.
+func Shutdown(ctx context.Context) error {
+	if v, ok := std.(Shutdowner); ok {
+		return v.Shutdown(ctx)
+	}
+	return shutdown(ctx, func() error {
+		if err := Sync(); err != nil {
+			return err
+		}
+		return Close()
+	})
+}
@@ -0,0 +1,107 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrorHandler is called when a message can not be encoded or written
+type ErrorHandler func(err error)
+
+// DefaultErrorHandler writes errors to stderr, not more often than once per second
+var DefaultErrorHandler = NewRateLimitErrorHandler(os.Stderr, time.Second)
+
+// NewRateLimitErrorHandler writes errors to w, not more often than once per interval.
+// Errors suppressed within the interval are counted and reported with the next error.
+func NewRateLimitErrorHandler(w io.Writer, interval time.Duration) ErrorHandler {
+	var (
+		mux        sync.Mutex
+		last       time.Time
+		suppressed uint64
+	)
+	return func(err error) {
+		mux.Lock()
+		defer mux.Unlock()
+
+		now := time.Now()
+		if !last.IsZero() && now.Sub(last) < interval {
+			suppressed++
+			return
+		}
+		last = now
+
+		if suppressed > 0 {
+			fmt.Fprintf(w, "%s %v (suppressed %d errors)\n", now.Format(time.RFC3339), err, suppressed) //nolint:errcheck
+			suppressed = 0
+			return
+		}
+		fmt.Fprintf(w, "%s %v\n", now.Format(time.RFC3339), err) //nolint:errcheck
+	}
+}
+
+// WithErrorHandler set handler of encode and write errors, nil restores DefaultErrorHandler
+func WithErrorHandler(h ErrorHandler) Option {
+	return func(c *config) error {
+		if h == nil {
+			h = DefaultErrorHandler
+		}
+		c.errorHandler = h
+		return nil
+	}
+}
+
+// WithFallback set writer used for messages which failed to be written to the output,
+// nil disables fallback
+func WithFallback(w io.Writer) Option {
+	return func(c *config) error {
+		c.fallback = w
+		return nil
+	}
+}
+
+// SetErrorHandler change handler of encode and write errors, nil restores DefaultErrorHandler
+func (l *Log) SetErrorHandler(h ErrorHandler) {
+	l.core.update(WithErrorHandler(h)) //nolint:errcheck
+}
+
+// SetFallback change writer used for messages which failed to be written to the output
+func (l *Log) SetFallback(w io.Writer) {
+	l.core.update(WithFallback(w)) //nolint:errcheck
+}
+
+// WriteErrors count of messages which failed to be written to the output
+func (l *Log) WriteErrors() uint64 {
+	return l.core.failures.Load()
+}
+
+func (l *Log) encode(conf *config, m *Message) {
+	for _, s := range conf.sinks {
+		if err := s.WriteMessage(m); err != nil {
+			l.core.failures.Add(1)
+			conf.errorHandler(fmt.Errorf("logx sink: %w", err))
+		}
+	}
+
+	err := conf.formatter.Encode(conf.writer, m)
+	if err == nil {
+		return
+	}
+	l.core.failures.Add(1)
+
+	if conf.fallback != nil {
+		if ferr := conf.formatter.Encode(conf.fallback, m); ferr != nil {
+			err = fmt.Errorf("%w; fallback: %w", err, ferr)
+		} else {
+			err = fmt.Errorf("%w; written to fallback", err)
+		}
+	}
+	conf.errorHandler(err)
+}
@@ -0,0 +1,68 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/logx"
+)
+
+type failWriter struct{}
+
+func (failWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestUnit_ErrorHandler(t *testing.T) {
+	var errs []error
+	fallback := newMockWriter()
+
+	l := logx.New()
+	casecheck.NoError(t, l.Reconfigure(
+		logx.WithOutput(failWriter{}),
+		logx.WithFormatter(logx.NewFormatString()),
+		logx.WithErrorHandler(func(err error) { errs = append(errs, err) }),
+	))
+
+	l.Error("first")
+	casecheck.Equal(t, uint64(1), l.WriteErrors())
+	casecheck.Equal(t, 1, len(errs))
+	casecheck.Contains(t, errs[0].Error(), "disk full")
+
+	l.SetFallback(fallback)
+	l.Error("second")
+	casecheck.Equal(t, uint64(2), l.WriteErrors())
+	casecheck.Equal(t, 2, len(errs))
+	casecheck.Contains(t, errs[1].Error(), "written to fallback")
+	casecheck.Contains(t, fallback.String(), "\"msg\"=\"second\"")
+
+	l.SetFallback(failWriter{})
+	l.Error("third")
+	casecheck.Contains(t, errs[2].Error(), "fallback: logx string write: disk full")
+}
+
+func TestUnit_RateLimitErrorHandler(t *testing.T) {
+	var w bytes.Buffer
+	h := logx.NewRateLimitErrorHandler(&w, 100*time.Millisecond)
+
+	h(errors.New("e1"))
+	h(errors.New("e2"))
+	h(errors.New("e3"))
+	time.Sleep(150 * time.Millisecond)
+	h(errors.New("e4"))
+
+	lines := strings.Split(strings.TrimSpace(w.String()), "\n")
+	casecheck.Equal(t, 2, len(lines))
+	casecheck.True(t, strings.HasSuffix(lines[0], " e1"))
+	casecheck.True(t, strings.HasSuffix(lines[1], " e4 (suppressed 2 errors)"))
+}
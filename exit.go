@@ -0,0 +1,74 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"os"
+	"sync"
+)
+
+type exitState struct {
+	mux   sync.Mutex
+	call  func(code int)
+	code  int
+	hooks []func()
+}
+
+func newExitState() *exitState {
+	return &exitState{
+		call: os.Exit,
+		code: 1,
+	}
+}
+
+// SetExitFunc change function called by Fatal, default os.Exit.
+// Output is flushed, not closed, before the call, so the logger stays usable if the function returns.
+func (l *Log) SetExitFunc(call func(code int)) {
+	l.core.exit.mux.Lock()
+	defer l.core.exit.mux.Unlock()
+
+	if call == nil {
+		call = os.Exit
+	}
+	l.core.exit.call = call
+}
+
+// SetExitCode change status code passed to exit function by Fatal, default 1
+func (l *Log) SetExitCode(code int) {
+	l.core.exit.mux.Lock()
+	defer l.core.exit.mux.Unlock()
+
+	l.core.exit.code = code
+}
+
+// OnExit register callback called by Fatal before exit,
+// callbacks are called in reverse order of registration, like defer.
+func (l *Log) OnExit(call func()) {
+	l.core.exit.mux.Lock()
+	defer l.core.exit.mux.Unlock()
+
+	l.core.exit.hooks = append(l.core.exit.hooks, call)
+}
+
+func (l *Log) exit() {
+	l.core.exit.mux.Lock()
+	hooks := append(make([]func(), 0, len(l.core.exit.hooks)), l.core.exit.hooks...)
+	call, code := l.core.exit.call, l.core.exit.code
+	l.core.exit.mux.Unlock()
+
+	for i := len(hooks) - 1; i >= 0; i-- {
+		runExitHook(hooks[i])
+	}
+	l.Sync() //nolint:errcheck
+	call(code)
+}
+
+func runExitHook(call func()) {
+	defer func() {
+		recover()
+	}()
+	call()
+}
@@ -0,0 +1,15 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import "time"
+
+// SetLevelAfterFunc replaces the timer of LevelHandler timeouts, returns the restore func
+func SetLevelAfterFunc(call func(d time.Duration, f func()) *time.Timer) func() {
+	prev := levelAfterFunc
+	levelAfterFunc = call
+	return func() { levelAfterFunc = prev }
+}
@@ -0,0 +1,178 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"go.osspkg.com/ioutils/pool"
+)
+
+// Binary formats write every record as 4 bytes big-endian size and the body,
+// the same framing as NetFramingLength. The body is a map:
+//
+//	time   timestamp
+//	level  string
+//	msg    string
+//	caller map of file, line and func, omitted when empty
+//	ctx    array of key, value pairs in the order of Message.Ctx
+//
+// Ctx keys are strings, values keep their type: nil, bool, integers, floats,
+// strings, []byte and time.Time, other values are written as strings.
+const (
+	binaryFrameHeader = 4
+	binaryMaxFrame    = 64 << 20
+	binaryMaxDepth    = 32
+)
+
+var (
+	errBinaryFrameSize = errors.New("frame is too big")
+	errBinaryDepth     = errors.New("nesting is too deep")
+	errBinaryTruncated = errors.New("truncated value")
+)
+
+type binaryBuffer struct {
+	b []byte
+}
+
+func (v *binaryBuffer) Reset() {
+	v.b = v.b[:0]
+}
+
+var poolBinary = pool.New[*binaryBuffer](func() *binaryBuffer {
+	return &binaryBuffer{b: make([]byte, 0, 1024)}
+})
+
+// writeBinaryFrame encodes the body after the size header and writes the frame with one Write
+func writeBinaryFrame(out io.Writer, encode func(b []byte) []byte) error {
+	buf := poolBinary.Get()
+	defer func() {
+		poolBinary.Put(buf)
+	}()
+
+	buf.b = encode(append(buf.b, 0, 0, 0, 0))
+	size := len(buf.b) - binaryFrameHeader
+	if size > binaryMaxFrame {
+		return errBinaryFrameSize
+	}
+	binary.BigEndian.PutUint32(buf.b, uint32(size)) //nolint:gosec
+	_, err := out.Write(buf.b)
+	return err
+}
+
+// binaryFrames reads length-delimited frames, io.EOF only between frames
+type binaryFrames struct {
+	r   *bufio.Reader
+	buf []byte
+}
+
+func newBinaryFrames(r io.Reader) binaryFrames {
+	return binaryFrames{r: bufio.NewReader(r)}
+}
+
+func (v *binaryFrames) next() ([]byte, error) {
+	var header [binaryFrameHeader]byte
+	if _, err := io.ReadFull(v.r, header[:]); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(header[:])
+	if size > binaryMaxFrame {
+		return nil, errBinaryFrameSize
+	}
+	if cap(v.buf) < int(size) {
+		v.buf = make([]byte, size)
+	}
+	v.buf = v.buf[:size]
+	if _, err := io.ReadFull(v.r, v.buf); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return v.buf, nil
+}
+
+// binaryValue native type of the ctx value: nil, bool, int64, uint64, float32, float64,
+// string, []byte or time.Time, other values as strings
+func binaryValue(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case nil, bool, int64, uint64, float32, float64, string, time.Time:
+		return v
+	case int:
+		return int64(vv)
+	case int8:
+		return int64(vv)
+	case int16:
+		return int64(vv)
+	case int32:
+		return int64(vv)
+	case uint:
+		return uint64(vv)
+	case uint8:
+		return uint64(vv)
+	case uint16:
+		return uint64(vv)
+	case uint32:
+		return uint64(vv)
+	case []byte:
+		return vv
+	default:
+		return ctxString(v)
+	}
+}
+
+// binaryCtx ctx pairs with string keys, an odd count is padded with nil
+func binaryCtx(ctx []interface{}, call func(key string, value interface{})) {
+	for i := 0; i < len(ctx); i += 2 {
+		var value interface{}
+		if i+1 < len(ctx) {
+			value = ctx[i+1]
+		}
+		call(ctxString(ctx[i]), value)
+	}
+}
+
+// binaryCtxLen size of the ctx array with the padding
+func binaryCtxLen(ctx []interface{}) int {
+	return len(ctx) + len(ctx)%2
+}
+
+// setBinaryField fills the message by the decoded field of the record, unknown fields are skipped
+func setBinaryField(m *Message, key string, value interface{}) error {
+	var ok bool
+	switch key {
+	case "time":
+		m.Time, ok = value.(time.Time)
+	case "level":
+		m.Level, ok = value.(string)
+	case "msg":
+		m.Message, ok = value.(string)
+	case "caller":
+		var caller map[string]interface{}
+		if caller, ok = value.(map[string]interface{}); ok {
+			m.Caller.File, _ = caller["file"].(string) //nolint:errcheck
+			m.Caller.Func, _ = caller["func"].(string) //nolint:errcheck
+			line, _ := caller["line"].(int64)          //nolint:errcheck
+			m.Caller.Line = int(line)
+		}
+	case "ctx":
+		var ctx []interface{}
+		if ctx, ok = value.([]interface{}); ok {
+			m.Ctx = append(m.Ctx, ctx...)
+		}
+	default:
+		ok = true
+	}
+	if !ok {
+		return fmt.Errorf("invalid %s field type %T", key, value)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/logx"
+)
+
+var binaryFormats = []struct {
+	name    string
+	format  logx.Formatter
+	decoder func(r io.Reader) logx.Decoder
+}{
+	{
+		name:    "cbor",
+		format:  logx.NewFormatCBOR(),
+		decoder: func(r io.Reader) logx.Decoder { return logx.NewCBORDecoder(r) },
+	},
+	{
+		name:    "msgpack",
+		format:  logx.NewFormatMsgpack(),
+		decoder: func(r io.Reader) logx.Decoder { return logx.NewMsgpackDecoder(r) },
+	},
+}
+
+func TestUnit_FormatBinary_RoundTrip(t *testing.T) {
+	ts := time.Date(2026, 1, 2, 3, 4, 5, 123456789, time.UTC)
+	long := strings.Repeat("x", 70000)
+
+	for _, tt := range binaryFormats {
+		t.Run(tt.name, func(t *testing.T) {
+			messages := []*logx.Message{
+				{
+					Time:    ts,
+					Level:   "INFO",
+					Message: "hello",
+					Caller:  logx.Caller{File: "/src/main.go", Line: 42, Func: "main.run"},
+					Ctx: []interface{}{
+						"int", 7, "neg", -300, "min", int64(math.MinInt64), "big", uint64(math.MaxUint64),
+						"u8", uint8(200), "f32", float32(1.5), "f64", 2.25, "bool", true, "nil", nil,
+						"bytes", []byte{0, 1, 2}, "time", ts, "dur", 1500 * time.Millisecond,
+						"err", errors.New("boom"), "long", long, "odd",
+					},
+				},
+				{Time: ts.Add(time.Second), Level: "ERROR", Message: ""},
+			}
+
+			buf := &bytes.Buffer{}
+			for _, m := range messages {
+				casecheck.NoError(t, tt.format.Encode(buf, m))
+			}
+
+			dec := tt.decoder(buf)
+			m, err := dec.Decode()
+			casecheck.NoError(t, err)
+			casecheck.True(t, ts.Equal(m.Time))
+			casecheck.Equal(t, "INFO", m.Level)
+			casecheck.Equal(t, "hello", m.Message)
+			casecheck.Equal(t, messages[0].Caller, m.Caller)
+			casecheck.Equal(t, 30, len(m.Ctx))
+
+			want := []interface{}{
+				"int", int64(7), "neg", int64(-300), "min", int64(math.MinInt64), "big", uint64(math.MaxUint64),
+				"u8", int64(200), "f32", float32(1.5), "f64", 2.25, "bool", true, "nil", nil,
+				"bytes", []byte{0, 1, 2},
+			}
+			casecheck.Equal(t, want, m.Ctx[:len(want)])
+			casecheck.True(t, ts.Equal(m.Ctx[21].(time.Time)))
+			casecheck.Equal(t, []interface{}{"dur", "1.5s", "err", "boom", "long", long, "odd", nil}, m.Ctx[22:])
+
+			m, err = dec.Decode()
+			casecheck.NoError(t, err)
+			casecheck.Equal(t, "ERROR", m.Level)
+			casecheck.True(t, m.Caller.IsZero())
+			casecheck.Equal(t, 0, len(m.Ctx))
+
+			_, err = dec.Decode()
+			casecheck.True(t, errors.Is(err, io.EOF))
+		})
+	}
+}
+
+func TestUnit_FormatBinary_Framing(t *testing.T) {
+	for _, tt := range binaryFormats {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			casecheck.NoError(t, tt.format.Encode(buf, &logx.Message{Level: "INFO", Message: "a"}))
+			b := buf.Bytes()
+			casecheck.Equal(t, len(b)-4, int(binary.BigEndian.Uint32(b)))
+		})
+	}
+}
+
+func TestUnit_FormatBinary_Malformed(t *testing.T) {
+	for _, tt := range binaryFormats {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			buf.Write([]byte{0, 0, 0, 2, 0xc1, 0xff}) // not a record
+			casecheck.NoError(t, tt.format.Encode(buf, &logx.Message{Level: "WARN", Message: "next"}))
+			casecheck.NoError(t, tt.format.Encode(buf, &logx.Message{Level: "WARN", Message: "cut"}))
+			buf.Truncate(buf.Len() - 3)
+
+			dec := tt.decoder(buf)
+			_, err := dec.Decode()
+			casecheck.Error(t, err)
+			casecheck.Contains(t, err.Error(), "logx "+tt.name+" decode")
+
+			m, err := dec.Decode()
+			casecheck.NoError(t, err)
+			casecheck.Equal(t, "next", m.Message)
+
+			_, err = dec.Decode()
+			casecheck.True(t, errors.Is(err, io.ErrUnexpectedEOF))
+		})
+	}
+}
+
+func TestUnit_FormatBinary_ToJSON(t *testing.T) {
+	for _, tt := range binaryFormats {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			casecheck.NoError(t, tt.format.Encode(buf, &logx.Message{
+				Time: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), Level: "INFO", Message: "hi",
+				Ctx: []interface{}{"n", 3},
+			}))
+			m, err := tt.decoder(buf).Decode()
+			casecheck.NoError(t, err)
+
+			out := &bytes.Buffer{}
+			casecheck.NoError(t, logx.NewFormatJSON().Encode(out, m))
+			casecheck.Equal(t,
+				`{"time":"2026-01-02T03:04:05Z","level":"INFO","msg":"hi","ctx":{"n":"3"}}`+"\n",
+				out.String())
+		})
+	}
+}
+
+func TestUnit_FormatCBOR_Bytes(t *testing.T) {
+	buf := &bytes.Buffer{}
+	casecheck.NoError(t, logx.NewFormatCBOR().Encode(buf, &logx.Message{
+		Time: time.Unix(0, 0).UTC(), Level: "I", Message: "m", Ctx: []interface{}{"k", -1},
+	}))
+	want := []byte{
+		0xa4,
+		0x64, 't', 'i', 'm', 'e', 0xc0, 0x74, '1', '9', '7', '0', '-', '0', '1', '-', '0', '1',
+		'T', '0', '0', ':', '0', '0', ':', '0', '0', 'Z',
+		0x65, 'l', 'e', 'v', 'e', 'l', 0x61, 'I',
+		0x63, 'm', 's', 'g', 0x61, 'm',
+		0x63, 'c', 't', 'x', 0x82, 0x61, 'k', 0x20,
+	}
+	casecheck.Equal(t, want, buf.Bytes()[4:])
+}
+
+func TestUnit_FormatMsgpack_Bytes(t *testing.T) {
+	buf := &bytes.Buffer{}
+	casecheck.NoError(t, logx.NewFormatMsgpack().Encode(buf, &logx.Message{
+		Time: time.Unix(1, 2).UTC(), Level: "I", Message: "m", Ctx: []interface{}{"k", -1},
+	}))
+	want := []byte{
+		0x84,
+		0xa4, 't', 'i', 'm', 'e', 0xc7, 12, 0xff, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 1,
+		0xa5, 'l', 'e', 'v', 'e', 'l', 0xa1, 'I',
+		0xa3, 'm', 's', 'g', 0xa1, 'm',
+		0xa3, 'c', 't', 'x', 0x92, 0xa1, 'k', 0xff,
+	}
+	casecheck.Equal(t, want, buf.Bytes()[4:])
+}
@@ -0,0 +1,360 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+const (
+	cborUint   byte = 0
+	cborNegInt byte = 1
+	cborBytes  byte = 2
+	cborText   byte = 3
+	cborArray  byte = 4
+	cborMap    byte = 5
+	cborTag    byte = 6
+	cborSimple byte = 7
+)
+
+// FormatCBOR length-delimited CBOR (RFC 8949) records, time is written as tag 0 RFC 3339 string,
+// see NewCBORDecoder to read them back
+type FormatCBOR struct{}
+
+func NewFormatCBOR() *FormatCBOR {
+	return &FormatCBOR{}
+}
+
+func (*FormatCBOR) Encode(out io.Writer, m *Message) error {
+	if err := writeBinaryFrame(out, func(b []byte) []byte {
+		return cborAppendMessage(b, m)
+	}); err != nil {
+		return fmt.Errorf("logx cbor write: %w", err)
+	}
+	return nil
+}
+
+// NewDecoder reads records of the formatter back, see NewCBORDecoder
+func (*FormatCBOR) NewDecoder(r io.Reader) Decoder {
+	return NewCBORDecoder(r)
+}
+
+func cborAppendMessage(b []byte, m *Message) []byte {
+	fields := 4
+	if !m.Caller.IsZero() {
+		fields++
+	}
+	b = cborAppendHead(b, cborMap, uint64(fields))
+	b = cborAppendValue(cborAppendText(b, "time"), m.Time)
+	b = cborAppendText(cborAppendText(b, "level"), m.Level)
+	b = cborAppendText(cborAppendText(b, "msg"), m.Message)
+	if !m.Caller.IsZero() {
+		b = cborAppendHead(cborAppendText(b, "caller"), cborMap, 3)
+		b = cborAppendText(cborAppendText(b, "file"), m.Caller.File)
+		b = cborAppendValue(cborAppendText(b, "line"), m.Caller.Line)
+		b = cborAppendText(cborAppendText(b, "func"), m.Caller.Func)
+	}
+	b = cborAppendHead(cborAppendText(b, "ctx"), cborArray, uint64(binaryCtxLen(m.Ctx)))
+	binaryCtx(m.Ctx, func(key string, value interface{}) {
+		b = cborAppendValue(cborAppendText(b, key), value)
+	})
+	return b
+}
+
+func cborAppendHead(b []byte, major byte, n uint64) []byte {
+	major <<= 5
+	switch {
+	case n < 24:
+		return append(b, major|byte(n))
+	case n <= math.MaxUint8:
+		return append(b, major|24, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, major|25), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, major|26), uint32(n))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, major|27), n)
+	}
+}
+
+func cborAppendText(b []byte, s string) []byte {
+	return append(cborAppendHead(b, cborText, uint64(len(s))), s...)
+}
+
+func cborAppendValue(b []byte, v interface{}) []byte {
+	switch vv := binaryValue(v).(type) {
+	case nil:
+		return append(b, 0xf6)
+	case bool:
+		if vv {
+			return append(b, 0xf5)
+		}
+		return append(b, 0xf4)
+	case int64:
+		if vv < 0 {
+			return cborAppendHead(b, cborNegInt, uint64(^vv))
+		}
+		return cborAppendHead(b, cborUint, uint64(vv))
+	case uint64:
+		return cborAppendHead(b, cborUint, vv)
+	case float32:
+		return binary.BigEndian.AppendUint32(append(b, 0xfa), math.Float32bits(vv))
+	case float64:
+		return binary.BigEndian.AppendUint64(append(b, 0xfb), math.Float64bits(vv))
+	case []byte:
+		return append(cborAppendHead(b, cborBytes, uint64(len(vv))), vv...)
+	case time.Time:
+		return cborAppendText(cborAppendHead(b, cborTag, 0), vv.Format(time.RFC3339Nano))
+	case string:
+		return cborAppendText(b, vv)
+	default:
+		return cborAppendText(b, ctxString(vv))
+	}
+}
+
+// CBORDecoder reads records of FormatCBOR
+type CBORDecoder struct {
+	frames binaryFrames
+}
+
+func NewCBORDecoder(r io.Reader) *CBORDecoder {
+	return &CBORDecoder{frames: newBinaryFrames(r)}
+}
+
+// Decode next record, io.EOF at the end of the stream.
+// A malformed record returns an error and the next call continues with the following one.
+func (v *CBORDecoder) Decode() (*Message, error) {
+	b, err := v.frames.next()
+	if err != nil {
+		if err == io.EOF {
+			return nil, err
+		}
+		return nil, fmt.Errorf("logx cbor decode: %w", err)
+	}
+	m, err := cborDecodeMessage(b)
+	if err != nil {
+		return nil, fmt.Errorf("logx cbor decode: %w", err)
+	}
+	return m, nil
+}
+
+func cborDecodeMessage(b []byte) (*Message, error) {
+	r := &cborReader{b: b}
+	major, n, err := r.head()
+	if err != nil {
+		return nil, err
+	}
+	if major != cborMap {
+		return nil, fmt.Errorf("record is not a map")
+	}
+	m := newMessage()
+	for i := uint64(0); i < n; i++ {
+		key, err := r.value(0)
+		if err != nil {
+			return nil, err
+		}
+		value, err := r.value(0)
+		if err != nil {
+			return nil, err
+		}
+		if err = setBinaryField(m, ctxString(key), value); err != nil {
+			return nil, err
+		}
+	}
+	if r.pos != len(b) {
+		return nil, fmt.Errorf("%d bytes after the record", len(b)-r.pos)
+	}
+	return m, nil
+}
+
+type cborReader struct {
+	b   []byte
+	pos int
+}
+
+func (v *cborReader) read(n uint64) ([]byte, error) {
+	if n > uint64(len(v.b)-v.pos) {
+		return nil, errBinaryTruncated
+	}
+	b := v.b[v.pos : v.pos+int(n)]
+	v.pos += int(n)
+	return b, nil
+}
+
+// head major type and argument of the item, for floats the argument is the raw bits
+func (v *cborReader) head() (byte, uint64, error) {
+	b, err := v.read(1)
+	if err != nil {
+		return 0, 0, err
+	}
+	major, info := b[0]>>5, b[0]&0x1f
+	if info < 24 {
+		return major, uint64(info), nil
+	}
+	if info > 27 {
+		return 0, 0, fmt.Errorf("unsupported additional info %d of major type %d", info, major)
+	}
+	if b, err = v.read(1 << (info - 24)); err != nil {
+		return 0, 0, err
+	}
+	switch len(b) {
+	case 1:
+		return major, uint64(b[0]), nil
+	case 2:
+		return major, uint64(binary.BigEndian.Uint16(b)), nil
+	case 4:
+		return major, uint64(binary.BigEndian.Uint32(b)), nil
+	default:
+		return major, binary.BigEndian.Uint64(b), nil
+	}
+}
+
+func (v *cborReader) value(depth int) (interface{}, error) {
+	if depth > binaryMaxDepth {
+		return nil, errBinaryDepth
+	}
+	start := v.pos
+	major, n, err := v.head()
+	if err != nil {
+		return nil, err
+	}
+	switch major {
+	case cborUint:
+		if n > math.MaxInt64 {
+			return n, nil
+		}
+		return int64(n), nil
+	case cborNegInt:
+		if n > math.MaxInt64 {
+			return nil, fmt.Errorf("negative integer overflows int64")
+		}
+		return -1 - int64(n), nil
+	case cborBytes:
+		b, err := v.read(n)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), b...), nil
+	case cborText:
+		b, err := v.read(n)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case cborArray:
+		if n > uint64(len(v.b)-v.pos) {
+			return nil, errBinaryTruncated
+		}
+		result := make([]interface{}, 0, n)
+		for i := uint64(0); i < n; i++ {
+			item, err := v.value(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, item)
+		}
+		return result, nil
+	case cborMap:
+		if n > uint64(len(v.b)-v.pos)/2 {
+			return nil, errBinaryTruncated
+		}
+		result := make(map[string]interface{}, n)
+		for i := uint64(0); i < n; i++ {
+			key, err := v.value(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			item, err := v.value(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			result[ctxString(key)] = item
+		}
+		return result, nil
+	case cborTag:
+		item, err := v.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return cborTagged(n, item)
+	default:
+		return cborSimpleValue(v.b[start], n)
+	}
+}
+
+// cborTagged date/time tags 0 and 1, other tags are ignored
+func cborTagged(tag uint64, item interface{}) (interface{}, error) {
+	switch tag {
+	case 0:
+		s, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("tag 0 of %T", item)
+		}
+		return time.Parse(time.RFC3339Nano, s)
+	case 1:
+		switch vv := item.(type) {
+		case int64:
+			return time.Unix(vv, 0).UTC(), nil
+		case float32:
+			return cborEpoch(float64(vv)), nil
+		case float64:
+			return cborEpoch(vv), nil
+		default:
+			return nil, fmt.Errorf("tag 1 of %T", item)
+		}
+	default:
+		return item, nil
+	}
+}
+
+func cborEpoch(f float64) time.Time {
+	sec, frac := math.Modf(f)
+	return time.Unix(int64(sec), int64(frac*1e9)).UTC()
+}
+
+func cborSimpleValue(initial byte, bits uint64) (interface{}, error) {
+	switch initial & 0x1f {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23:
+		return nil, nil
+	case 25:
+		return cborHalfFloat(uint16(bits)), nil
+	case 26:
+		return math.Float32frombits(uint32(bits)), nil
+	case 27:
+		return math.Float64frombits(bits), nil
+	default:
+		return nil, fmt.Errorf("unsupported simple value 0x%02x", initial)
+	}
+}
+
+func cborHalfFloat(h uint16) float32 {
+	exp, mant := (h>>10)&0x1f, float64(h&0x3ff)
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mant, -24)
+	case 0x1f:
+		if mant == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mant+1024, int(exp)-25)
+	}
+	if h&0x8000 != 0 {
+		f = -f
+	}
+	return float32(f)
+}
@@ -0,0 +1,240 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"go.osspkg.com/ioutils/data"
+)
+
+// CloudProvider preset of FormatCloud
+type CloudProvider string
+
+const (
+	// CloudGCP Google Cloud Logging structured JSON
+	CloudGCP CloudProvider = "gcp"
+	// CloudAWS CloudWatch Logs JSON with flat fields, usable by metric filters and EMF
+	CloudAWS CloudProvider = "aws"
+)
+
+const (
+	gcpTrace          = "logging.googleapis.com/trace"
+	gcpSpanID         = "logging.googleapis.com/spanId"
+	gcpSourceLocation = "logging.googleapis.com/sourceLocation"
+)
+
+// gcpSeverities level names which are GCP severities too
+var gcpSeverities = map[string]struct{}{
+	"DEBUG": {}, "INFO": {}, "NOTICE": {}, "WARNING": {}, "ERROR": {}, "CRITICAL": {}, "ALERT": {}, "EMERGENCY": {},
+}
+
+// gcpHTTPRequest ctx keys of AccessLog and fields of the httpRequest object
+var gcpHTTPRequest = map[string]string{
+	"method":      "requestMethod",
+	"path":        "requestUrl",
+	"status":      "status",
+	"size":        "responseSize",
+	"duration":    "latency",
+	"remote_addr": "remoteIp",
+	"user_agent":  "userAgent",
+}
+
+// FormatCloud JSON lines parsed by cloud platforms from stdout.
+//
+// GCP: severity (WARNING, ERROR...), message, time, logging.googleapis.com/trace and spanId
+// from the trace_id and span_id ctx keys, logging.googleapis.com/sourceLocation from the caller
+// and httpRequest from the fields of AccessLog (method, path, status...).
+//
+// AWS: timestamp, level, message, location, xray_trace_id from trace_id and other ctx fields
+// at the top level, numbers are kept as numbers and the _aws key is written as is for EMF.
+type FormatCloud struct {
+	provider CloudProvider
+	project  string
+}
+
+func NewFormatCloud(provider CloudProvider) *FormatCloud {
+	return &FormatCloud{provider: provider}
+}
+
+// SetProject GCP project id, trace ids are written as projects/<id>/traces/<trace_id>
+func (v *FormatCloud) SetProject(id string) {
+	v.project = id
+}
+
+func (v *FormatCloud) Encode(out io.Writer, m *Message) error {
+	w := poolBuffer.Get()
+	defer func() {
+		poolBuffer.Put(w)
+	}()
+
+	var err error
+	switch v.provider {
+	case CloudGCP:
+		err = v.encodeGCP(w, m)
+	case CloudAWS:
+		err = v.encodeAWS(w, m)
+	default:
+		err = fmt.Errorf("unknown provider %q", v.provider)
+	}
+	if err != nil {
+		return fmt.Errorf("logx cloud encode: %w", err)
+	}
+
+	if _, err = out.Write(w.Bytes()); err != nil {
+		return fmt.Errorf("logx cloud write: %w", err)
+	}
+	return nil
+}
+
+func (v *FormatCloud) encodeGCP(w *data.Buffer, m *Message) error {
+	doc := make(map[string]interface{}, len(m.Ctx)/2+2)
+	if !m.Caller.IsZero() {
+		doc[gcpSourceLocation] = map[string]string{
+			"file":     m.Caller.File,
+			"line":     strconv.Itoa(m.Caller.Line),
+			"function": m.Caller.Func,
+		}
+	}
+	var httpRequest map[string]interface{}
+	for i := 0; i < len(m.Ctx); i += 2 {
+		var value interface{}
+		if i+1 < len(m.Ctx) {
+			value = m.Ctx[i+1]
+		}
+		key := ctxString(m.Ctx[i])
+		switch {
+		case isTraceKey(key):
+			if len(v.project) > 0 {
+				doc[gcpTrace] = "projects/" + v.project + "/traces/" + ctxString(value)
+			} else {
+				doc[gcpTrace] = ctxString(value)
+			}
+		case isSpanKey(key):
+			doc[gcpSpanID] = ctxString(value)
+		case len(gcpHTTPRequest[key]) > 0:
+			if httpRequest == nil {
+				httpRequest = make(map[string]interface{}, len(gcpHTTPRequest))
+			}
+			httpRequest[gcpHTTPRequest[key]] = gcpHTTPValue(key, value)
+		default:
+			doc[key] = jsonScalar(value)
+		}
+	}
+	// a single field like path is not a request, keep it as is
+	if _, ok := httpRequest["requestMethod"]; ok {
+		doc["httpRequest"] = httpRequest
+	} else {
+		for key, field := range gcpHTTPRequest {
+			if value, ok := httpRequest[field]; ok {
+				doc[key] = value
+			}
+		}
+	}
+
+	w.WriteString(`{"severity":`) //nolint:errcheck
+	writeJSONString(w, gcpSeverity(m.Level))
+	w.WriteString(`,"message":`) //nolint:errcheck
+	writeJSONString(w, m.Message)
+	w.WriteString(`,"time":`) //nolint:errcheck
+	writeJSONString(w, m.Time.UTC().Format(time.RFC3339Nano))
+	return writeJSONRest(w, doc, "severity", "message", "time")
+}
+
+func (v *FormatCloud) encodeAWS(w *data.Buffer, m *Message) error {
+	doc := make(map[string]interface{}, len(m.Ctx)/2+1)
+	if !m.Caller.IsZero() {
+		doc["location"] = m.Caller.String()
+	}
+	for i := 0; i < len(m.Ctx); i += 2 {
+		var value interface{}
+		if i+1 < len(m.Ctx) {
+			value = m.Ctx[i+1]
+		}
+		key := ctxString(m.Ctx[i])
+		switch {
+		case key == "_aws":
+			doc[key] = value
+		case isTraceKey(key):
+			doc["xray_trace_id"] = ctxString(value)
+		default:
+			doc[key] = jsonScalar(value)
+		}
+	}
+
+	w.WriteString(`{"timestamp":`) //nolint:errcheck
+	writeJSONString(w, m.Time.UTC().Format(time.RFC3339Nano))
+	w.WriteString(`,"level":`) //nolint:errcheck
+	writeJSONString(w, m.Level)
+	w.WriteString(`,"message":`) //nolint:errcheck
+	writeJSONString(w, m.Message)
+	return writeJSONRest(w, doc, "timestamp", "level", "message")
+}
+
+// writeJSONRest fields of doc except the written ones, closes the object and the line
+func writeJSONRest(w *data.Buffer, doc map[string]interface{}, written ...string) error {
+	for _, key := range written {
+		delete(doc, key)
+	}
+	if len(doc) > 0 {
+		b, err := json.Marshal(doc)
+		if err != nil {
+			return err
+		}
+		w.WriteByte(',')         //nolint:errcheck
+		w.Write(b[1 : len(b)-1]) //nolint:errcheck
+	}
+	w.WriteString("}\n") //nolint:errcheck
+	return nil
+}
+
+// gcpSeverity level names known by GCP as is, others by the level severity
+func gcpSeverity(name string) string {
+	if _, ok := gcpSeverities[name]; ok {
+		return name
+	}
+	level, err := ParseLevel(name)
+	if err != nil {
+		return "DEFAULT"
+	}
+	switch uint32(level.Severity()) {
+	case LevelFatal:
+		return "CRITICAL"
+	case LevelError:
+		return "ERROR"
+	case LevelWarn:
+		return "WARNING"
+	case LevelInfo:
+		return "INFO"
+	default:
+		return "DEBUG"
+	}
+}
+
+// gcpHTTPValue status as a number, size as a string and latency as seconds with the s suffix
+func gcpHTTPValue(key string, value interface{}) interface{} {
+	switch key {
+	case "status":
+		return jsonScalar(value)
+	case "duration":
+		if d, ok := value.(time.Duration); ok {
+			return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+		}
+	}
+	return ctxString(value)
+}
+
+func isTraceKey(key string) bool {
+	return key == "trace_id" || key == "traceId" || key == "trace.id"
+}
+
+func isSpanKey(key string) bool {
+	return key == "span_id" || key == "spanId" || key == "span.id"
+}
@@ -0,0 +1,107 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/logx"
+)
+
+func TestUnit_FormatCloudGCP(t *testing.T) {
+	f := logx.NewFormatCloud(logx.CloudGCP)
+	f.SetProject("my-project")
+
+	m := &logx.Message{
+		Time:    time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Level:   "WARN",
+		Message: "slow request",
+		Caller:  logx.Caller{File: "/src/app/main.go", Line: 42, Func: "main.run"},
+		Ctx: []interface{}{
+			"trace_id", "4bf92f3577b34da6a3ce929d0e0e4736", "span_id", "00f067aa0ba902b7",
+			"method", "GET", "path", "/api", "status", 200, "size", 512,
+			"duration", 1500 * time.Millisecond, "remote_addr", "10.0.0.1:5000", "user_agent", "curl",
+			"user", "bob",
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	casecheck.NoError(t, f.Encode(buf, m))
+	casecheck.True(t, strings.HasPrefix(buf.String(),
+		`{"severity":"WARNING","message":"slow request","time":"2026-01-02T03:04:05Z",`))
+	casecheck.True(t, strings.HasSuffix(buf.String(), "}\n"))
+
+	var doc map[string]interface{}
+	casecheck.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	casecheck.Equal(t, "projects/my-project/traces/4bf92f3577b34da6a3ce929d0e0e4736", doc["logging.googleapis.com/trace"])
+	casecheck.Equal(t, "00f067aa0ba902b7", doc["logging.googleapis.com/spanId"])
+	casecheck.Equal(t, map[string]interface{}{
+		"file": "/src/app/main.go", "line": "42", "function": "main.run",
+	}, doc["logging.googleapis.com/sourceLocation"])
+	casecheck.Equal(t, map[string]interface{}{
+		"requestMethod": "GET", "requestUrl": "/api", "status": float64(200), "responseSize": "512",
+		"latency": "1.5s", "remoteIp": "10.0.0.1:5000", "userAgent": "curl",
+	}, doc["httpRequest"])
+	casecheck.Equal(t, "bob", doc["user"])
+	casecheck.Nil(t, doc["method"])
+}
+
+func TestUnit_FormatCloudGCPSeverity(t *testing.T) {
+	f := logx.NewFormatCloud(logx.CloudGCP)
+	for level, want := range map[string]string{
+		"FATAL": "CRITICAL", "PANIC": "CRITICAL", "ERROR": "ERROR", "INFO": "INFO",
+		"DEBUG": "DEBUG", "TRACE": "DEBUG", "UNKNOWN": "DEFAULT",
+	} {
+		buf := &bytes.Buffer{}
+		casecheck.NoError(t, f.Encode(buf, &logx.Message{Level: level, Message: "x", Ctx: []interface{}{"path", "/a"}}))
+		var doc map[string]interface{}
+		casecheck.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+		casecheck.Equal(t, want, doc["severity"])
+		casecheck.Equal(t, "/a", doc["path"])
+		casecheck.Nil(t, doc["httpRequest"])
+	}
+}
+
+func TestUnit_FormatCloudAWS(t *testing.T) {
+	f := logx.NewFormatCloud(logx.CloudAWS)
+
+	m := &logx.Message{
+		Time:    time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Level:   "INFO",
+		Message: "done",
+		Caller:  logx.Caller{File: "main.go", Line: 7},
+		Ctx: []interface{}{
+			"trace_id", "1-5759e988-bd862e3fe1be46a994272793", "latency_ms", 12.5, "ok", true, "user", 42,
+			"_aws", map[string]interface{}{"Timestamp": 1767323045000},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	casecheck.NoError(t, f.Encode(buf, m))
+	casecheck.True(t, strings.HasPrefix(buf.String(),
+		`{"timestamp":"2026-01-02T03:04:05Z","level":"INFO","message":"done",`))
+
+	var doc map[string]interface{}
+	casecheck.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	casecheck.Equal(t, "1-5759e988-bd862e3fe1be46a994272793", doc["xray_trace_id"])
+	casecheck.Equal(t, "main.go:7", doc["location"])
+	casecheck.Equal(t, 12.5, doc["latency_ms"])
+	casecheck.Equal(t, true, doc["ok"])
+	casecheck.Equal(t, float64(42), doc["user"])
+	casecheck.Equal(t, map[string]interface{}{"Timestamp": float64(1767323045000)}, doc["_aws"])
+}
+
+func TestUnit_FormatCloudUnknown(t *testing.T) {
+	err := logx.NewFormatCloud("azure").Encode(&bytes.Buffer{}, &logx.Message{})
+	casecheck.Error(t, err)
+	casecheck.Contains(t, err.Error(), "unknown provider")
+}
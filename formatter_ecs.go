@@ -0,0 +1,172 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+// ECSVersion version of Elastic Common Schema written by FormatECS
+const ECSVersion = "8.11.0"
+
+// ecsFieldSets top-level ECS objects, ctx keys like trace.id or service.name are placed at their paths
+var ecsFieldSets = map[string]struct{}{
+	"agent": {}, "client": {}, "cloud": {}, "container": {}, "destination": {}, "event": {},
+	"file": {}, "host": {}, "http": {}, "orchestrator": {}, "process": {}, "server": {},
+	"service": {}, "source": {}, "span": {}, "trace": {}, "transaction": {}, "url": {},
+	"user": {}, "user_agent": {},
+}
+
+// FormatECS Elastic Common Schema (ecs-logging) JSON lines.
+// Caller is written to log.origin, the first error value of ctx to error.*,
+// ECS keys (trace.id, service.name, host.name...) to their paths and other fields to labels.
+type FormatECS struct {
+	namespace string
+}
+
+func NewFormatECS() *FormatECS {
+	return &FormatECS{namespace: "labels"}
+}
+
+// SetNamespace object for fields without ECS mapping, default labels.
+// Values of labels are strings, a custom namespace keeps numbers and booleans.
+func (v *FormatECS) SetNamespace(name string) {
+	v.namespace = name
+}
+
+func (v *FormatECS) Encode(out io.Writer, m *Message) error {
+	doc := make(map[string]interface{}, 4)
+	if !m.Caller.IsZero() {
+		origin := map[string]interface{}{
+			"file": map[string]interface{}{"name": m.Caller.File, "line": m.Caller.Line},
+		}
+		if len(m.Caller.Func) > 0 {
+			origin["function"] = m.Caller.Func
+		}
+		doc["log"] = map[string]interface{}{"origin": origin}
+	}
+
+	hasError := false
+	for i := 0; i < len(m.Ctx); i += 2 {
+		var value interface{}
+		if i+1 < len(m.Ctx) {
+			value = m.Ctx[i+1]
+		}
+		key := ctxString(m.Ctx[i])
+		if err, ok := value.(error); ok && !hasError {
+			doc["error"] = ecsError(err)
+			hasError = true
+			continue
+		}
+		if i := strings.IndexByte(key, '.'); i > 0 {
+			if _, ok := ecsFieldSets[key[:i]]; ok && setPath(doc, key, ctxString(value)) {
+				continue
+			}
+		}
+		v.setField(doc, key, value)
+	}
+
+	rest, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("logx ecs encode: %w", err)
+	}
+
+	w := poolBuffer.Get()
+	defer func() {
+		poolBuffer.Put(w)
+	}()
+
+	w.WriteString(`{"@timestamp":`) //nolint:errcheck
+	writeJSONString(w, m.Time.UTC().Format(time.RFC3339Nano))
+	w.WriteString(`,"log.level":`) //nolint:errcheck
+	writeJSONString(w, strings.ToLower(m.Level))
+	w.WriteString(`,"message":`) //nolint:errcheck
+	writeJSONString(w, m.Message)
+	w.WriteString(`,"ecs.version":"` + ECSVersion + `"`) //nolint:errcheck
+	if len(rest) > 2 {
+		w.WriteByte(',')               //nolint:errcheck
+		w.Write(rest[1 : len(rest)-1]) //nolint:errcheck
+	}
+	w.WriteString("}\n") //nolint:errcheck
+
+	if _, err = out.Write(w.Bytes()); err != nil {
+		return fmt.Errorf("logx ecs write: %w", err)
+	}
+	return nil
+}
+
+func (v *FormatECS) setField(doc map[string]interface{}, key string, value interface{}) {
+	ns, ok := doc[v.namespace].(map[string]interface{})
+	if !ok {
+		ns = make(map[string]interface{})
+		doc[v.namespace] = ns
+	}
+	if v.namespace == "labels" {
+		ns[key] = ctxString(value)
+		return
+	}
+	ns[key] = jsonScalar(value)
+}
+
+// ecsError message, type and the stack trace of errors formatted with %+v, like pkg/errors
+func ecsError(err error) map[string]interface{} {
+	result := map[string]interface{}{
+		"message": err.Error(),
+		"type":    fmt.Sprintf("%T", err),
+	}
+	if trace := fmt.Sprintf("%+v", err); trace != err.Error() {
+		result["stack_trace"] = trace
+	}
+	return result
+}
+
+// setPath put value to nested objects by the dotted path, false if the path is taken by a value
+func setPath(doc map[string]interface{}, path string, value interface{}) bool {
+	keys := strings.Split(path, ".")
+	for _, key := range keys[:len(keys)-1] {
+		switch next := doc[key].(type) {
+		case map[string]interface{}:
+			doc = next
+		case nil:
+			obj := make(map[string]interface{})
+			doc[key] = obj
+			doc = obj
+		default:
+			return false
+		}
+	}
+	last := keys[len(keys)-1]
+	if _, ok := doc[last].(map[string]interface{}); ok {
+		return false
+	}
+	doc[last] = value
+	return true
+}
+
+// jsonScalar numbers and booleans as is, other values as strings
+func jsonScalar(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, bool:
+		return v
+	case float32:
+		if math.IsNaN(float64(vv)) || math.IsInf(float64(vv), 0) {
+			return ctxString(v)
+		}
+		return v
+	case float64:
+		if math.IsNaN(vv) || math.IsInf(vv, 0) {
+			return ctxString(v)
+		}
+		return v
+	default:
+		return ctxString(v)
+	}
+}
@@ -0,0 +1,65 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/logx"
+)
+
+type stackError struct{}
+
+func (stackError) Error() string { return "broken" }
+
+func (e stackError) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		fmt.Fprint(s, "broken\nmain.go:10") //nolint:errcheck
+		return
+	}
+	fmt.Fprint(s, e.Error()) //nolint:errcheck
+}
+
+func TestUnit_FormatECS(t *testing.T) {
+	m := &logx.Message{
+		Time:    time.Date(2026, 1, 2, 3, 4, 5, 6000000, time.UTC),
+		Level:   "ERROR",
+		Message: "request failed",
+		Caller:  logx.Caller{File: "/src/app/main.go", Line: 42, Func: "main.run"},
+		Ctx: []interface{}{
+			"trace.id", "abc", "service.name", "api", "host.name", "h1",
+			"err", stackError{}, "user", "u1", "count", 3,
+		},
+	}
+
+	var w bytes.Buffer
+	casecheck.NoError(t, logx.NewFormatECS().Encode(&w, m))
+	casecheck.Equal(t, `{"@timestamp":"2026-01-02T03:04:05.006Z","log.level":"error","message":"request failed",`+
+		`"ecs.version":"`+logx.ECSVersion+`",`+
+		`"error":{"message":"broken","stack_trace":"broken\nmain.go:10","type":"logx_test.stackError"},`+
+		`"host":{"name":"h1"},"labels":{"count":"3","user":"u1"},`+
+		`"log":{"origin":{"file":{"line":42,"name":"/src/app/main.go"},"function":"main.run"}},`+
+		`"service":{"name":"api"},"trace":{"id":"abc"}}`+"\n", w.String())
+
+	f := logx.NewFormatECS()
+	f.SetNamespace("app")
+	w.Reset()
+	m.Caller = logx.Caller{}
+	m.Ctx = []interface{}{"count", 3, "ok", true, "error", errors.New("plain"), "host.name.full", "x", "host.name", "h2"}
+	casecheck.NoError(t, f.Encode(&w, m))
+	out := w.String()
+	casecheck.Contains(t, out, `"app":{"count":3,"host.name":"h2","ok":true}`)
+	casecheck.Contains(t, out, `"error":{"message":"plain","type":"*errors.errorString"}`)
+	casecheck.Contains(t, out, `"host":{"name":{"full":"x"}}`)
+	casecheck.False(t, strings.Contains(out, "stack_trace"))
+}
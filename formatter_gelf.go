@@ -0,0 +1,123 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"go.osspkg.com/ioutils/data"
+)
+
+// FormatGELF Graylog Extended Log Format 1.1, ctx fields are written as _-prefixed additional fields
+type FormatGELF struct {
+	host string
+}
+
+func NewFormatGELF() *FormatGELF {
+	host, _ := os.Hostname() //nolint:errcheck
+	return &FormatGELF{host: host}
+}
+
+// SetHost change the host field, default os.Hostname
+func (v *FormatGELF) SetHost(host string) {
+	v.host = host
+}
+
+func (v *FormatGELF) Encode(out io.Writer, m *Message) error {
+	w := poolBuffer.Get()
+	defer func() {
+		poolBuffer.Put(w)
+	}()
+
+	short, full := m.Message, ""
+	if i := strings.IndexByte(m.Message, '\n'); i >= 0 {
+		short, full = m.Message[:i], m.Message
+	}
+	level, _ := ParseLevel(m.Level) //nolint:errcheck
+
+	w.WriteString(`{"version":"1.1","host":`) //nolint:errcheck
+	writeJSONString(w, v.host)
+	w.WriteString(`,"short_message":`) //nolint:errcheck
+	writeJSONString(w, short)
+	if len(full) > 0 {
+		w.WriteString(`,"full_message":`) //nolint:errcheck
+		writeJSONString(w, full)
+	}
+	w.WriteString(`,"timestamp":`)                                                  //nolint:errcheck
+	w.WriteString(strconv.FormatFloat(float64(m.Time.UnixMicro())/1e6, 'f', 6, 64)) //nolint:errcheck
+	w.WriteString(`,"level":` + strconv.Itoa(SyslogSeverity(level.Uint32())))       //nolint:errcheck
+	w.WriteString(`,"_level_name":`)                                                //nolint:errcheck
+	writeJSONString(w, m.Level)
+	if !m.Caller.IsZero() {
+		w.WriteString(`,"_caller":`) //nolint:errcheck
+		writeJSONString(w, m.Caller.String())
+	}
+	for i := 0; i < len(m.Ctx); i += 2 {
+		var value interface{}
+		if i+1 < len(m.Ctx) {
+			value = m.Ctx[i+1]
+		}
+		w.WriteString(`,"` + gelfFieldName(ctxString(m.Ctx[i])) + `":`) //nolint:errcheck
+		writeJSONValue(w, value)
+	}
+	w.WriteString("}\n") //nolint:errcheck
+
+	if _, err := out.Write(w.Bytes()); err != nil {
+		return fmt.Errorf("logx gelf write: %w", err)
+	}
+	return nil
+}
+
+// gelfFieldName _-prefixed name of letters, digits, '_', '.' and '-', _id is reserved by GELF
+func gelfFieldName(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '.', r == '-':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+	if s == "id" {
+		s = "id_"
+	}
+	return "_" + s
+}
+
+func writeJSONString(w *data.Buffer, s string) {
+	b, _ := json.Marshal(s) //nolint:errcheck
+	w.Write(b)              //nolint:errcheck
+}
+
+// writeJSONValue numbers as is, other values as strings
+func writeJSONValue(w *data.Buffer, v interface{}) {
+	switch vv := v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		w.WriteString(fmt.Sprintf("%d", vv)) //nolint:errcheck
+	case float32:
+		writeJSONFloat(w, float64(vv), 32)
+	case float64:
+		writeJSONFloat(w, vv, 64)
+	default:
+		writeJSONString(w, ctxString(v))
+	}
+}
+
+// writeJSONFloat NaN and Inf are written as strings, they are not valid JSON numbers
+func writeJSONFloat(w *data.Buffer, f float64, bitSize int) {
+	s := strconv.FormatFloat(f, 'g', -1, bitSize)
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		writeJSONString(w, s)
+		return
+	}
+	w.WriteString(s) //nolint:errcheck
+}
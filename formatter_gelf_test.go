@@ -0,0 +1,150 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx_test
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/logx"
+)
+
+func TestUnit_FormatGELF(t *testing.T) {
+	f := logx.NewFormatGELF()
+	f.SetHost("host1")
+
+	var w bytes.Buffer
+	casecheck.NoError(t, f.Encode(&w, &logx.Message{
+		Time:    time.Unix(1700000000, 250000000),
+		Level:   "WARN",
+		Message: "first line\nsecond line",
+		Ctx:     []interface{}{"id", "x", "user name", "u1", "n", 10, "f", 1.5, "nan", math.NaN()},
+	}))
+	casecheck.Equal(t, `{"version":"1.1","host":"host1","short_message":"first line",`+
+		`"full_message":"first line\nsecond line","timestamp":1700000000.250000,"level":4,"_level_name":"WARN",`+
+		`"_id_":"x","_user_name":"u1","_n":10,"_f":1.5,"_nan":"NaN"}`+"\n", w.String())
+
+	var doc map[string]interface{}
+	casecheck.NoError(t, json.Unmarshal(w.Bytes(), &doc))
+}
+
+func TestUnit_GELFUDPChunking(t *testing.T) {
+	for _, compression := range []string{logx.GELFCompressNone, logx.GELFCompressGzip, logx.GELFCompressZlib} {
+		t.Run(compression, func(t *testing.T) {
+			conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+			casecheck.NoError(t, err)
+			defer conn.Close() //nolint:errcheck
+
+			w, err := logx.NewGELFWriter(logx.GELFOptions{
+				Address:     conn.LocalAddr().String(),
+				Compression: compression,
+				ChunkSize:   512,
+			})
+			casecheck.NoError(t, err)
+			defer w.Close() //nolint:errcheck
+
+			f := logx.NewFormatGELF()
+			l := logx.New(logx.WithOutput(w), logx.WithFormatter(f), logx.WithLevel(logx.LevelInfo))
+			payload := randomText(4096)
+			l.Info("big", "payload", payload)
+
+			var (
+				chunks = make(map[byte][]byte)
+				count  = 1
+				buf    = make([]byte, 2048)
+			)
+			for len(chunks) < count {
+				casecheck.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+				n, _, err := conn.ReadFrom(buf)
+				casecheck.NoError(t, err)
+				casecheck.True(t, n <= 512)
+				b := append([]byte(nil), buf[:n]...)
+				if !bytes.HasPrefix(b, []byte{0x1e, 0x0f}) {
+					chunks[0] = b
+					break
+				}
+				count = int(b[11])
+				chunks[b[10]] = b[12:]
+			}
+			casecheck.True(t, count > 1)
+
+			var data []byte
+			for i := 0; i < count; i++ {
+				data = append(data, chunks[byte(i)]...)
+			}
+			var r io.Reader = bytes.NewReader(data)
+			switch compression {
+			case logx.GELFCompressGzip:
+				r, err = gzip.NewReader(r)
+			case logx.GELFCompressZlib:
+				r, err = zlib.NewReader(r)
+			}
+			casecheck.NoError(t, err)
+
+			var doc map[string]interface{}
+			casecheck.NoError(t, json.NewDecoder(r).Decode(&doc))
+			casecheck.Equal(t, "big", doc["short_message"])
+			casecheck.Equal(t, payload, doc["_payload"])
+		})
+	}
+}
+
+func TestUnit_GELFTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	casecheck.NoError(t, err)
+	defer ln.Close() //nolint:errcheck
+
+	w, err := logx.NewGELFWriter(logx.GELFOptions{Network: "tcp", Address: ln.Addr().String()})
+	casecheck.NoError(t, err)
+	defer w.Close() //nolint:errcheck
+
+	l := logx.New(logx.WithOutput(w), logx.WithFormatter(logx.NewFormatGELF()), logx.WithLevel(logx.LevelInfo))
+	l.Info("one")
+	l.Error("two")
+
+	conn, err := ln.Accept()
+	casecheck.NoError(t, err)
+	defer conn.Close() //nolint:errcheck
+	casecheck.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	r := bufio.NewReader(conn)
+	for _, msg := range []string{"one", "two"} {
+		frame, err := r.ReadBytes(0)
+		casecheck.NoError(t, err)
+		var doc map[string]interface{}
+		casecheck.NoError(t, json.Unmarshal(frame[:len(frame)-1], &doc))
+		casecheck.Equal(t, msg, doc["short_message"])
+	}
+}
+
+// randomText poorly compressible text
+func randomText(n int) string {
+	var b strings.Builder
+	var seed [8]byte
+	x := uint64(88172645463325252)
+	for b.Len() < n {
+		x ^= x << 13
+		x ^= x >> 7
+		x ^= x << 17
+		binary.LittleEndian.PutUint64(seed[:], x)
+		for _, c := range seed {
+			b.WriteByte('a' + c%26)
+		}
+	}
+	return b.String()
+}
@@ -8,6 +8,7 @@ package logx
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 
@@ -70,3 +71,131 @@ type jsonWriter struct {
 func (v *jsonWriter) Reset() {
 	v.Buffer.Reset()
 }
+
+// NewDecoder reads records of the formatter back, see NewJSONDecoder
+func (*FormatJSON) NewDecoder(r io.Reader) Decoder {
+	return NewJSONDecoder(r)
+}
+
+// JSONDecoder reads records of FormatJSON, one object per line.
+// Fields of ctx are decoded in the order of the line, unknown top-level fields are added to Ctx too.
+type JSONDecoder struct {
+	lines lineReader
+}
+
+func NewJSONDecoder(r io.Reader) *JSONDecoder {
+	return &JSONDecoder{lines: newLineReader(r)}
+}
+
+func (v *JSONDecoder) Decode() (*Message, error) {
+	b, err := v.lines.next()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("logx json decode: %w", err)
+	}
+	m, err := jsonDecodeMessage(b)
+	if err != nil {
+		return nil, fmt.Errorf("logx json decode: line %d: %w", v.lines.line, err)
+	}
+	return m, nil
+}
+
+func jsonDecodeMessage(b []byte) (*Message, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := jsonDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+	m := newMessage()
+	for dec.More() {
+		key, err := jsonKey(dec)
+		if err != nil {
+			return nil, err
+		}
+		switch key {
+		case "time":
+			err = dec.Decode(&m.Time)
+		case "level":
+			err = dec.Decode(&m.Level)
+		case "msg":
+			err = dec.Decode(&m.Message)
+		case "caller":
+			err = dec.Decode(&m.Caller)
+		case "ctx":
+			err = jsonDecodeCtx(dec, m)
+		default:
+			var value interface{}
+			if err = dec.Decode(&value); err == nil {
+				m.Ctx = append(m.Ctx, key, jsonNative(value))
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", key, err)
+		}
+	}
+	if err := jsonDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("data after the object")
+	}
+	return m, nil
+}
+
+func jsonDecodeCtx(dec *json.Decoder, m *Message) error {
+	if err := jsonDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		key, err := jsonKey(dec)
+		if err != nil {
+			return err
+		}
+		var value interface{}
+		if err = dec.Decode(&value); err != nil {
+			return err
+		}
+		m.Ctx = append(m.Ctx, key, jsonNative(value))
+	}
+	return jsonDelim(dec, '}')
+}
+
+func jsonDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected %s, got %v", delim, token)
+	}
+	return nil
+}
+
+func jsonKey(dec *json.Decoder) (string, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return "", err
+	}
+	key, ok := token.(string)
+	if !ok {
+		return "", fmt.Errorf("expected key, got %v", token)
+	}
+	return key, nil
+}
+
+// jsonNative numbers as int64 or float64, other values as decoded
+func jsonNative(v interface{}) interface{} {
+	n, ok := v.(json.Number)
+	if !ok {
+		return v
+	}
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+	if f, err := n.Float64(); err == nil {
+		return f
+	}
+	return n.String()
+}
@@ -0,0 +1,406 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// msgpackExtTimestamp type -1 of the timestamp extension
+const msgpackExtTimestamp byte = 0xff
+
+// FormatMsgpack length-delimited MessagePack records, time is written as the timestamp extension,
+// see NewMsgpackDecoder to read them back
+type FormatMsgpack struct{}
+
+func NewFormatMsgpack() *FormatMsgpack {
+	return &FormatMsgpack{}
+}
+
+func (*FormatMsgpack) Encode(out io.Writer, m *Message) error {
+	if err := writeBinaryFrame(out, func(b []byte) []byte {
+		return msgpackAppendMessage(b, m)
+	}); err != nil {
+		return fmt.Errorf("logx msgpack write: %w", err)
+	}
+	return nil
+}
+
+// NewDecoder reads records of the formatter back, see NewMsgpackDecoder
+func (*FormatMsgpack) NewDecoder(r io.Reader) Decoder {
+	return NewMsgpackDecoder(r)
+}
+
+func msgpackAppendMessage(b []byte, m *Message) []byte {
+	fields := 4
+	if !m.Caller.IsZero() {
+		fields++
+	}
+	b = msgpackAppendHead(b, 0x80, 0xde, fields)
+	b = msgpackAppendValue(msgpackAppendString(b, "time"), m.Time)
+	b = msgpackAppendString(msgpackAppendString(b, "level"), m.Level)
+	b = msgpackAppendString(msgpackAppendString(b, "msg"), m.Message)
+	if !m.Caller.IsZero() {
+		b = msgpackAppendHead(msgpackAppendString(b, "caller"), 0x80, 0xde, 3)
+		b = msgpackAppendString(msgpackAppendString(b, "file"), m.Caller.File)
+		b = msgpackAppendValue(msgpackAppendString(b, "line"), m.Caller.Line)
+		b = msgpackAppendString(msgpackAppendString(b, "func"), m.Caller.Func)
+	}
+	b = msgpackAppendHead(msgpackAppendString(b, "ctx"), 0x90, 0xdc, binaryCtxLen(m.Ctx))
+	binaryCtx(m.Ctx, func(key string, value interface{}) {
+		b = msgpackAppendValue(msgpackAppendString(b, key), value)
+	})
+	return b
+}
+
+// msgpackAppendHead map or array header: fix type for sizes below 16, 16 and 32 bit sizes
+func msgpackAppendHead(b []byte, fix, code byte, n int) []byte {
+	switch {
+	case n < 16:
+		return append(b, fix|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, code), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(b, code+1), uint32(n)) //nolint:gosec
+	}
+}
+
+func msgpackAppendString(b []byte, s string) []byte {
+	switch n := len(s); {
+	case n < 32:
+		b = append(b, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		b = append(b, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		b = binary.BigEndian.AppendUint16(append(b, 0xda), uint16(n))
+	default:
+		b = binary.BigEndian.AppendUint32(append(b, 0xdb), uint32(n)) //nolint:gosec
+	}
+	return append(b, s...)
+}
+
+func msgpackAppendUint(b []byte, n uint64) []byte {
+	switch {
+	case n <= math.MaxInt8:
+		return append(b, byte(n))
+	case n <= math.MaxUint8:
+		return append(b, 0xcc, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, 0xcd), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, 0xce), uint32(n))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, 0xcf), n)
+	}
+}
+
+func msgpackAppendInt(b []byte, n int64) []byte {
+	switch {
+	case n >= 0:
+		return msgpackAppendUint(b, uint64(n))
+	case n >= -32:
+		return append(b, byte(n))
+	case n >= math.MinInt8:
+		return append(b, 0xd0, byte(n))
+	case n >= math.MinInt16:
+		return binary.BigEndian.AppendUint16(append(b, 0xd1), uint16(n))
+	case n >= math.MinInt32:
+		return binary.BigEndian.AppendUint32(append(b, 0xd2), uint32(n))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, 0xd3), uint64(n))
+	}
+}
+
+func msgpackAppendValue(b []byte, v interface{}) []byte {
+	switch vv := binaryValue(v).(type) {
+	case nil:
+		return append(b, 0xc0)
+	case bool:
+		if vv {
+			return append(b, 0xc3)
+		}
+		return append(b, 0xc2)
+	case int64:
+		return msgpackAppendInt(b, vv)
+	case uint64:
+		return msgpackAppendUint(b, vv)
+	case float32:
+		return binary.BigEndian.AppendUint32(append(b, 0xca), math.Float32bits(vv))
+	case float64:
+		return binary.BigEndian.AppendUint64(append(b, 0xcb), math.Float64bits(vv))
+	case []byte:
+		switch n := len(vv); {
+		case n <= math.MaxUint8:
+			b = append(b, 0xc4, byte(n))
+		case n <= math.MaxUint16:
+			b = binary.BigEndian.AppendUint16(append(b, 0xc5), uint16(n))
+		default:
+			b = binary.BigEndian.AppendUint32(append(b, 0xc6), uint32(n)) //nolint:gosec
+		}
+		return append(b, vv...)
+	case time.Time:
+		// timestamp 96: nanoseconds uint32 and seconds int64
+		b = append(b, 0xc7, 12, msgpackExtTimestamp)
+		b = binary.BigEndian.AppendUint32(b, uint32(vv.Nanosecond())) //nolint:gosec
+		return binary.BigEndian.AppendUint64(b, uint64(vv.Unix()))    //nolint:gosec
+	case string:
+		return msgpackAppendString(b, vv)
+	default:
+		return msgpackAppendString(b, ctxString(vv))
+	}
+}
+
+// MsgpackDecoder reads records of FormatMsgpack
+type MsgpackDecoder struct {
+	frames binaryFrames
+}
+
+func NewMsgpackDecoder(r io.Reader) *MsgpackDecoder {
+	return &MsgpackDecoder{frames: newBinaryFrames(r)}
+}
+
+// Decode next record, io.EOF at the end of the stream.
+// A malformed record returns an error and the next call continues with the following one.
+func (v *MsgpackDecoder) Decode() (*Message, error) {
+	b, err := v.frames.next()
+	if err != nil {
+		if err == io.EOF {
+			return nil, err
+		}
+		return nil, fmt.Errorf("logx msgpack decode: %w", err)
+	}
+	m, err := msgpackDecodeMessage(b)
+	if err != nil {
+		return nil, fmt.Errorf("logx msgpack decode: %w", err)
+	}
+	return m, nil
+}
+
+func msgpackDecodeMessage(b []byte) (*Message, error) {
+	r := &msgpackReader{b: b}
+	value, err := r.value(0)
+	if err != nil {
+		return nil, err
+	}
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("record is not a map")
+	}
+	if r.pos != len(b) {
+		return nil, fmt.Errorf("%d bytes after the record", len(b)-r.pos)
+	}
+	m := newMessage()
+	for _, key := range [...]string{"time", "level", "msg", "caller", "ctx"} {
+		if value, ok = fields[key]; !ok {
+			continue
+		}
+		if err = setBinaryField(m, key, value); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+type msgpackReader struct {
+	b   []byte
+	pos int
+}
+
+func (v *msgpackReader) read(n uint64) ([]byte, error) {
+	if n > uint64(len(v.b)-v.pos) {
+		return nil, errBinaryTruncated
+	}
+	b := v.b[v.pos : v.pos+int(n)]
+	v.pos += int(n)
+	return b, nil
+}
+
+// size big-endian unsigned integer of 1, 2, 4 or 8 bytes
+func (v *msgpackReader) size(n uint64) (uint64, error) {
+	b, err := v.read(n)
+	if err != nil {
+		return 0, err
+	}
+	switch n {
+	case 1:
+		return uint64(b[0]), nil
+	case 2:
+		return uint64(binary.BigEndian.Uint16(b)), nil
+	case 4:
+		return uint64(binary.BigEndian.Uint32(b)), nil
+	default:
+		return binary.BigEndian.Uint64(b), nil
+	}
+}
+
+func (v *msgpackReader) value(depth int) (interface{}, error) {
+	if depth > binaryMaxDepth {
+		return nil, errBinaryDepth
+	}
+	b, err := v.read(1)
+	if err != nil {
+		return nil, err
+	}
+	c := b[0]
+	switch {
+	case c <= 0x7f:
+		return int64(c), nil
+	case c >= 0xe0:
+		return int64(int8(c)), nil
+	case c <= 0x8f:
+		return v.mapValue(uint64(c&0x0f), depth)
+	case c <= 0x9f:
+		return v.arrayValue(uint64(c&0x0f), depth)
+	case c <= 0xbf:
+		return v.stringValue(uint64(c & 0x1f))
+	}
+
+	switch c {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := v.size(1 << (c - 0xc4))
+		if err != nil {
+			return nil, err
+		}
+		if b, err = v.read(n); err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), b...), nil
+	case 0xc7, 0xc8, 0xc9:
+		n, err := v.size(1 << (c - 0xc7))
+		if err != nil {
+			return nil, err
+		}
+		return v.extValue(n)
+	case 0xca:
+		n, err := v.size(4)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float32frombits(uint32(n)), nil
+	case 0xcb:
+		n, err := v.size(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(n), nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		n, err := v.size(1 << (c - 0xcc))
+		if err != nil {
+			return nil, err
+		}
+		if n > math.MaxInt64 {
+			return n, nil
+		}
+		return int64(n), nil
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		width := uint64(1) << (c - 0xd0)
+		n, err := v.size(width)
+		if err != nil {
+			return nil, err
+		}
+		// sign extension of the width bytes
+		shift := 64 - 8*width
+		return int64(n<<shift) >> shift, nil //nolint:gosec
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return v.extValue(1 << (c - 0xd4))
+	case 0xd9, 0xda, 0xdb:
+		n, err := v.size(1 << (c - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return v.stringValue(n)
+	case 0xdc, 0xdd:
+		n, err := v.size(2 << (c - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return v.arrayValue(n, depth)
+	case 0xde, 0xdf:
+		n, err := v.size(2 << (c - 0xde))
+		if err != nil {
+			return nil, err
+		}
+		return v.mapValue(n, depth)
+	default:
+		return nil, fmt.Errorf("unsupported type 0x%02x", c)
+	}
+}
+
+func (v *msgpackReader) stringValue(n uint64) (interface{}, error) {
+	b, err := v.read(n)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (v *msgpackReader) arrayValue(n uint64, depth int) (interface{}, error) {
+	if n > uint64(len(v.b)-v.pos) {
+		return nil, errBinaryTruncated
+	}
+	result := make([]interface{}, 0, n)
+	for i := uint64(0); i < n; i++ {
+		item, err := v.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+func (v *msgpackReader) mapValue(n uint64, depth int) (interface{}, error) {
+	if n > uint64(len(v.b)-v.pos)/2 {
+		return nil, errBinaryTruncated
+	}
+	result := make(map[string]interface{}, n)
+	for i := uint64(0); i < n; i++ {
+		key, err := v.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		item, err := v.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		result[ctxString(key)] = item
+	}
+	return result, nil
+}
+
+// extValue timestamp extension as time.Time, data of other extensions as []byte
+func (v *msgpackReader) extValue(n uint64) (interface{}, error) {
+	b, err := v.read(n + 1)
+	if err != nil {
+		return nil, err
+	}
+	typ, data := b[0], b[1:]
+	if typ != msgpackExtTimestamp {
+		return append([]byte(nil), data...), nil
+	}
+	switch len(data) {
+	case 4:
+		return time.Unix(int64(binary.BigEndian.Uint32(data)), 0).UTC(), nil
+	case 8:
+		n := binary.BigEndian.Uint64(data)
+		return time.Unix(int64(n&(1<<34-1)), int64(n>>34)).UTC(), nil
+	case 12:
+		sec := int64(binary.BigEndian.Uint64(data[4:])) //nolint:gosec
+		return time.Unix(sec, int64(binary.BigEndian.Uint32(data[:4]))).UTC(), nil
+	default:
+		return nil, fmt.Errorf("invalid timestamp size %d", len(data))
+	}
+}
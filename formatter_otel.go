@@ -0,0 +1,248 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const otelScopeName = "go.osspkg.com/logx"
+
+// OTel severity numbers of the log data model
+const (
+	OTelSeverityTrace = 1
+	OTelSeverityDebug = 5
+	OTelSeverityInfo  = 9
+	OTelSeverityWarn  = 13
+	OTelSeverityError = 17
+	OTelSeverityFatal = 21
+)
+
+type otelRequest struct {
+	ResourceLogs []otelResourceLogs `json:"resourceLogs"`
+}
+
+type otelResourceLogs struct {
+	Resource  otelResource    `json:"resource"`
+	ScopeLogs []otelScopeLogs `json:"scopeLogs"`
+}
+
+type otelResource struct {
+	Attributes []otelKeyValue `json:"attributes,omitempty"`
+}
+
+type otelScopeLogs struct {
+	Scope      otelScope       `json:"scope"`
+	LogRecords []otelLogRecord `json:"logRecords"`
+}
+
+type otelScope struct {
+	Name string `json:"name"`
+}
+
+type otelLogRecord struct {
+	TimeUnixNano         string         `json:"timeUnixNano"`
+	ObservedTimeUnixNano string         `json:"observedTimeUnixNano"`
+	SeverityNumber       int            `json:"severityNumber"`
+	SeverityText         string         `json:"severityText"`
+	Body                 otelAnyValue   `json:"body"`
+	Attributes           []otelKeyValue `json:"attributes,omitempty"`
+	TraceID              string         `json:"traceId,omitempty"`
+	SpanID               string         `json:"spanId,omitempty"`
+}
+
+type otelKeyValue struct {
+	Key   string       `json:"key"`
+	Value otelAnyValue `json:"value"`
+}
+
+type otelAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+// FormatOTel OpenTelemetry log data model, every line is an OTLP/JSON ExportLogsServiceRequest
+// with one record, the format of the collector otlpjsonfile receiver.
+// Ctx keys trace_id and span_id (or traceId, trace.id...) are written as traceId and spanId,
+// caller as code.filepath, code.lineno and code.function attributes.
+type FormatOTel struct {
+	resource []otelKeyValue
+}
+
+func NewFormatOTel() *FormatOTel {
+	return &FormatOTel{}
+}
+
+// SetResource attributes of the resource, e.g. service.name
+func (v *FormatOTel) SetResource(attrs map[string]string) {
+	v.resource = otelResourceAttributes(attrs)
+}
+
+func (v *FormatOTel) Encode(out io.Writer, m *Message) error {
+	b, err := json.Marshal(otelBuildRequest(v.resource, []*Message{m}))
+	if err != nil {
+		return fmt.Errorf("logx otel encode: %w", err)
+	}
+	b = append(b, '\n')
+	if _, err = out.Write(b); err != nil {
+		return fmt.Errorf("logx otel write: %w", err)
+	}
+	return nil
+}
+
+// OTLPEncoder batch encoder of the OTLP/HTTP JSON ExportLogsServiceRequest, see NewOTLPExporter
+type OTLPEncoder struct {
+	// Resource attributes, e.g. service.name
+	Resource map[string]string
+}
+
+func (v *OTLPEncoder) ContentType() string {
+	return "application/json"
+}
+
+func (v *OTLPEncoder) EncodeBatch(w io.Writer, batch []*Message) error {
+	if err := json.NewEncoder(w).Encode(otelBuildRequest(otelResourceAttributes(v.Resource), batch)); err != nil {
+		return fmt.Errorf("logx otlp encode: %w", err)
+	}
+	return nil
+}
+
+// NewOTLPExporter batching sink posting to the OTLP/HTTP endpoint, e.g. http://collector:4318,
+// /v1/logs is added to endpoints without a path. The URL and Encoder of opts are replaced.
+func NewOTLPExporter(endpoint string, resource map[string]string, opts HTTPSinkOptions) (*HTTPSink, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("logx otlp: %w", err)
+	}
+	if len(strings.Trim(u.Path, "/")) == 0 {
+		u.Path = "/v1/logs"
+	}
+	opts.URL = u.String()
+	opts.Encoder = &OTLPEncoder{Resource: resource}
+	return NewHTTPSink(opts)
+}
+
+func otelResourceAttributes(attrs map[string]string) []otelKeyValue {
+	result := make([]otelKeyValue, 0, len(attrs))
+	for _, k := range sortedKeys(attrs) {
+		result = append(result, otelKeyValue{Key: k, Value: otelValue(attrs[k])})
+	}
+	return result
+}
+
+func otelBuildRequest(resource []otelKeyValue, batch []*Message) otelRequest {
+	records := make([]otelLogRecord, 0, len(batch))
+	for _, m := range batch {
+		records = append(records, otelRecord(m))
+	}
+	return otelRequest{ResourceLogs: []otelResourceLogs{{
+		Resource: otelResource{Attributes: resource},
+		ScopeLogs: []otelScopeLogs{{
+			Scope:      otelScope{Name: otelScopeName},
+			LogRecords: records,
+		}},
+	}}}
+}
+
+func otelRecord(m *Message) otelLogRecord {
+	ts := strconv.FormatInt(m.Time.UnixNano(), 10)
+	level, _ := ParseLevel(m.Level) //nolint:errcheck
+	r := otelLogRecord{
+		TimeUnixNano:         ts,
+		ObservedTimeUnixNano: ts,
+		SeverityNumber:       OTelSeverity(level.Uint32()),
+		SeverityText:         m.Level,
+		Body:                 otelValue(m.Message),
+		Attributes:           make([]otelKeyValue, 0, len(m.Ctx)/2+3),
+	}
+	if !m.Caller.IsZero() {
+		r.Attributes = append(r.Attributes,
+			otelKeyValue{Key: "code.filepath", Value: otelValue(m.Caller.File)},
+			otelKeyValue{Key: "code.lineno", Value: otelValue(m.Caller.Line)},
+		)
+		if len(m.Caller.Func) > 0 {
+			r.Attributes = append(r.Attributes, otelKeyValue{Key: "code.function", Value: otelValue(m.Caller.Func)})
+		}
+	}
+	for i := 0; i < len(m.Ctx); i += 2 {
+		var value interface{}
+		if i+1 < len(m.Ctx) {
+			value = m.Ctx[i+1]
+		}
+		key := ctxString(m.Ctx[i])
+		switch key {
+		case "trace_id", "traceId", "trace.id":
+			if id := ctxString(value); isHexID(id, 32) {
+				r.TraceID = strings.ToLower(id)
+				continue
+			}
+		case "span_id", "spanId", "span.id":
+			if id := ctxString(value); isHexID(id, 16) {
+				r.SpanID = strings.ToLower(id)
+				continue
+			}
+		}
+		r.Attributes = append(r.Attributes, otelKeyValue{Key: key, Value: otelValue(value)})
+	}
+	return r
+}
+
+// OTelSeverity number of the level in the OpenTelemetry log data model
+func OTelSeverity(level uint32) int {
+	if level == LevelTrace {
+		return OTelSeverityTrace
+	}
+	switch uint32(Level(level).Severity()) {
+	case LevelFatal:
+		return OTelSeverityFatal
+	case LevelError:
+		return OTelSeverityError
+	case LevelWarn:
+		return OTelSeverityWarn
+	case LevelInfo:
+		return OTelSeverityInfo
+	default:
+		return OTelSeverityDebug
+	}
+}
+
+func otelValue(v interface{}) otelAnyValue {
+	switch vv := v.(type) {
+	case bool:
+		return otelAnyValue{BoolValue: &vv}
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		s := fmt.Sprintf("%d", vv)
+		return otelAnyValue{IntValue: &s}
+	case float32:
+		f := float64(vv)
+		if !math.IsNaN(f) && !math.IsInf(f, 0) {
+			return otelAnyValue{DoubleValue: &f}
+		}
+	case float64:
+		if !math.IsNaN(vv) && !math.IsInf(vv, 0) {
+			return otelAnyValue{DoubleValue: &vv}
+		}
+	}
+	s := ctxString(v)
+	return otelAnyValue{StringValue: &s}
+}
+
+func isHexID(s string, size int) bool {
+	if len(s) != size || strings.Trim(s, "0") == "" {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
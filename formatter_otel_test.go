@@ -0,0 +1,118 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/logx"
+)
+
+type otelPayload struct {
+	ResourceLogs []struct {
+		Resource struct {
+			Attributes []otelAttr `json:"attributes"`
+		} `json:"resource"`
+		ScopeLogs []struct {
+			Scope struct {
+				Name string `json:"name"`
+			} `json:"scope"`
+			LogRecords []struct {
+				TimeUnixNano   string            `json:"timeUnixNano"`
+				SeverityNumber int               `json:"severityNumber"`
+				SeverityText   string            `json:"severityText"`
+				Body           map[string]string `json:"body"`
+				Attributes     []otelAttr        `json:"attributes"`
+				TraceID        string            `json:"traceId"`
+				SpanID         string            `json:"spanId"`
+			} `json:"logRecords"`
+		} `json:"scopeLogs"`
+	} `json:"resourceLogs"`
+}
+
+type otelAttr struct {
+	Key   string                 `json:"key"`
+	Value map[string]interface{} `json:"value"`
+}
+
+func TestUnit_FormatOTel(t *testing.T) {
+	f := logx.NewFormatOTel()
+	f.SetResource(map[string]string{"service.name": "api"})
+
+	var w bytes.Buffer
+	casecheck.NoError(t, f.Encode(&w, &logx.Message{
+		Time:    time.Unix(1700000000, 123),
+		Level:   "WARN",
+		Message: "slow query",
+		Caller:  logx.Caller{File: "/src/db.go", Line: 7},
+		Ctx: []interface{}{
+			"trace_id", "4BF92F3577B34DA6A3CE929D0E0E4736", "span_id", "00f067aa0ba902b7",
+			"rows", 10, "ok", true, "ratio", 0.5, "user", "u1",
+		},
+	}))
+
+	var p otelPayload
+	casecheck.NoError(t, json.Unmarshal(w.Bytes(), &p))
+	casecheck.Equal(t, "service.name", p.ResourceLogs[0].Resource.Attributes[0].Key)
+	casecheck.Equal(t, "go.osspkg.com/logx", p.ResourceLogs[0].ScopeLogs[0].Scope.Name)
+
+	r := p.ResourceLogs[0].ScopeLogs[0].LogRecords[0]
+	casecheck.Equal(t, "1700000000000000123", r.TimeUnixNano)
+	casecheck.Equal(t, logx.OTelSeverityWarn, r.SeverityNumber)
+	casecheck.Equal(t, "WARN", r.SeverityText)
+	casecheck.Equal(t, "slow query", r.Body["stringValue"])
+	casecheck.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", r.TraceID)
+	casecheck.Equal(t, "00f067aa0ba902b7", r.SpanID)
+
+	attrs := make(map[string]map[string]interface{}, len(r.Attributes))
+	for _, a := range r.Attributes {
+		attrs[a.Key] = a.Value
+	}
+	casecheck.Equal(t, 6, len(attrs))
+	casecheck.Equal(t, "/src/db.go", attrs["code.filepath"]["stringValue"])
+	casecheck.Equal(t, "7", attrs["code.lineno"]["intValue"])
+	casecheck.Equal(t, "10", attrs["rows"]["intValue"])
+	casecheck.Equal(t, true, attrs["ok"]["boolValue"])
+	casecheck.Equal(t, 0.5, attrs["ratio"]["doubleValue"])
+	casecheck.Equal(t, "u1", attrs["user"]["stringValue"])
+
+	casecheck.Equal(t, logx.OTelSeverityTrace, logx.OTelSeverity(logx.LevelTrace))
+	casecheck.Equal(t, logx.OTelSeverityFatal, logx.OTelSeverity(logx.LevelPanic))
+}
+
+func TestUnit_OTLPExporter(t *testing.T) {
+	srv := &pushServer{}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	s, err := logx.NewOTLPExporter(ts.URL, map[string]string{"service.name": "api"}, logx.HTTPSinkOptions{Gzip: true})
+	casecheck.NoError(t, err)
+	l := logx.New(logx.WithOutput(io.Discard), logx.WithLevel(logx.LevelInfo), logx.WithSinks(s))
+	l.Info("one", "trace_id", "not-a-trace-id")
+	l.Error("two")
+	casecheck.NoError(t, l.Close())
+
+	bodies, headers := srv.requests()
+	casecheck.Equal(t, "/v1/logs", srv.paths[0])
+	casecheck.Equal(t, 1, len(bodies))
+	casecheck.Equal(t, "application/json", headers[0].Get("Content-Type"))
+
+	var p otelPayload
+	casecheck.NoError(t, json.Unmarshal(bodies[0], &p))
+	records := p.ResourceLogs[0].ScopeLogs[0].LogRecords
+	casecheck.Equal(t, 2, len(records))
+	casecheck.Equal(t, "one", records[0].Body["stringValue"])
+	casecheck.Equal(t, "", records[0].TraceID)
+	casecheck.Equal(t, "trace_id", records[0].Attributes[0].Key)
+	casecheck.Equal(t, logx.OTelSeverityError, records[1].SeverityNumber)
+}
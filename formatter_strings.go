@@ -7,9 +7,10 @@ package logx
 
 import (
 	"encoding"
+	"errors"
 	"fmt"
 	"io"
-	"strings"
+	"strconv"
 	"time"
 
 	"go.osspkg.com/ioutils/data"
@@ -45,6 +46,9 @@ func (v *FormatString) Encode(out io.Writer, m *Message) error {
 	v.write(w, "time", m.Time.Format(time.RFC3339))
 	v.write(w, "level", m.Level)
 	v.write(w, "msg", m.Message)
+	if !m.Caller.IsZero() {
+		v.write(w, "caller", m.Caller.String())
+	}
 
 	if count := len(m.Ctx); count > 0 {
 		if count%2 != 0 {
@@ -87,5 +91,106 @@ func typing(v interface{}) string {
 	}
 
 	s := fmt.Sprintf("%#v", v)
-	return strings.Trim(s, "\"")
+	// only the quotes of the string literal, an escaped quote at the end belongs to the value
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// NewDecoder reads records of the formatter back with the same delimiter, see NewStringDecoder
+func (v *FormatString) NewDecoder(r io.Reader) Decoder {
+	dec := NewStringDecoder(r)
+	dec.SetDelimiter(v.delim)
+	return dec
+}
+
+// StringDecoder reads records of FormatString, one record of "key"="value" pairs per line.
+// Values are unquoted strings, fields after time, level, msg and caller are added to Ctx in order.
+type StringDecoder struct {
+	lines lineReader
+	delim byte
+}
+
+func NewStringDecoder(r io.Reader) *StringDecoder {
+	return &StringDecoder{lines: newLineReader(r), delim: '\t'}
+}
+
+// SetDelimiter of the pairs, the same as FormatString.SetDelimiter
+func (v *StringDecoder) SetDelimiter(d byte) {
+	v.delim = d
+}
+
+func (v *StringDecoder) Decode() (*Message, error) {
+	b, err := v.lines.next()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("logx string decode: %w", err)
+	}
+	m, err := v.decodeMessage(string(b))
+	if err != nil {
+		return nil, fmt.Errorf("logx string decode: line %d: %w", v.lines.line, err)
+	}
+	return m, nil
+}
+
+func (v *StringDecoder) decodeMessage(line string) (*Message, error) {
+	m := newMessage()
+	for pos := 0; pos < len(line); {
+		key, next, err := stringQuoted(line, pos)
+		if err != nil {
+			return nil, err
+		}
+		if next >= len(line) || line[next] != '=' {
+			return nil, fmt.Errorf("expected = at %d", next)
+		}
+		value, next, err := stringQuoted(line, next+1)
+		if err != nil {
+			return nil, err
+		}
+		if next < len(line) && line[next] != v.delim {
+			return nil, fmt.Errorf("expected delimiter at %d", next)
+		}
+		pos = next + 1
+
+		switch key {
+		case "time":
+			m.Time, err = time.Parse(time.RFC3339, value)
+		case "level":
+			m.Level = value
+		case "msg":
+			m.Message = value
+		case "caller":
+			err = m.Caller.UnmarshalText([]byte(value))
+		default:
+			m.Ctx = append(m.Ctx, key, value)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", key, err)
+		}
+	}
+	return m, nil
+}
+
+// stringQuoted value between quotes starting at pos and the position after the closing quote.
+// Values are written with Go escapes, text which is not a valid Go string is returned as is.
+func stringQuoted(line string, pos int) (string, int, error) {
+	if pos >= len(line) || line[pos] != '"' {
+		return "", pos, fmt.Errorf("expected quote at %d", pos)
+	}
+	for i := pos + 1; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '"':
+			raw := line[pos+1 : i]
+			if s, err := strconv.Unquote(`"` + raw + `"`); err == nil {
+				return s, i + 1, nil
+			}
+			return raw, i + 1, nil
+		}
+	}
+	return "", pos, fmt.Errorf("unterminated quote at %d", pos)
 }
@@ -53,6 +53,30 @@ func TestUnit_FormatString_Encode(t *testing.T) {
 	}
 }
 
+func TestUnit_FormatString_Quotes(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  string
+		// before output of the formatter trimming all quotes at the ends, the escaped ones too
+		before string
+	}{
+		{value: "plain", want: `"v"="plain"`, before: `"v"="plain"`},
+		{value: 7, want: `"v"="7"`, before: `"v"="7"`},
+		{value: `say "hi"`, want: `"v"="say \"hi\""`, before: `"v"="say \"hi\"`},
+		{value: `"quoted"`, want: `"v"="\"quoted\""`, before: `"v"="\"quoted\"`},
+		{value: `"`, want: `"v"="\""`, before: `"v"="\"`},
+	}
+	for _, tt := range tests {
+		var w bytes.Buffer
+		casecheck.NoError(t, logx.NewFormatString().Encode(&w, &logx.Message{Ctx: []interface{}{"v", tt.value}}))
+		got := w.String()
+		casecheck.Contains(t, got, tt.want+"\t")
+		if tt.before != tt.want {
+			casecheck.False(t, bytes.Contains(w.Bytes(), []byte(tt.before+"\t")))
+		}
+	}
+}
+
 func TestUnit_debug(t *testing.T) {
 	t.SkipNow()
 
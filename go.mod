@@ -7,6 +7,7 @@ require (
 	go.osspkg.com/casecheck v0.3.0
 	go.osspkg.com/ioutils v0.7.4
 	go.osspkg.com/syncing v0.4.3
+	golang.org/x/sys v0.41.0
 )
 
 require github.com/josharian/intern v1.0.0 // indirect
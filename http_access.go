@@ -0,0 +1,205 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// HeaderRequestID default header of the request id
+	HeaderRequestID = "X-Request-Id"
+
+	redactedValue = "[REDACTED]"
+)
+
+// AccessLogOptions settings of AccessLog, zero values use the defaults
+type AccessLogOptions struct {
+	// Message of the records, default "http request"
+	Message string
+	// Level by response status, default 5xx - Error, 4xx - Warn, others - Info
+	Level func(status int) uint32
+	// Skip requests with these exact paths, e.g. /health
+	SkipPaths []string
+	// RequestIDHeader is read from the request or generated, and set to the response, default X-Request-Id
+	RequestIDHeader string
+	// RequestHeaders and ResponseHeaders to add as req_header.<name> and resp_header.<name> fields
+	RequestHeaders  []string
+	ResponseHeaders []string
+	// RedactHeaders values are replaced with [REDACTED],
+	// default Authorization, Proxy-Authorization, Cookie and Set-Cookie
+	RedactHeaders []string
+}
+
+// DefaultAccessLevel 5xx - Error, 4xx - Warn, others - Info
+func DefaultAccessLevel(status int) uint32 {
+	switch {
+	case status >= 500:
+		return LevelError
+	case status >= 400:
+		return LevelWarn
+	default:
+		return LevelInfo
+	}
+}
+
+type accessLog struct {
+	log     Logger
+	next    http.Handler
+	opts    AccessLogOptions
+	skip    map[string]struct{}
+	redact  map[string]struct{}
+	message string
+}
+
+// AccessLog returns middleware which logs every request after it is served:
+// method, path, status, size, duration, remote_addr, user_agent and request_id.
+// Handlers get the logger with the request_id field by FromContext(r.Context()),
+// when l is *Log, otherwise l itself.
+func AccessLog(l Logger, opts AccessLogOptions) func(next http.Handler) http.Handler {
+	if opts.Level == nil {
+		opts.Level = DefaultAccessLevel
+	}
+	if len(opts.RequestIDHeader) == 0 {
+		opts.RequestIDHeader = HeaderRequestID
+	}
+	if len(opts.Message) == 0 {
+		opts.Message = "http request"
+	}
+	redact := opts.RedactHeaders
+	if redact == nil {
+		redact = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+	}
+	v := &accessLog{
+		log:    l,
+		opts:   opts,
+		skip:   make(map[string]struct{}, len(opts.SkipPaths)),
+		redact: make(map[string]struct{}, len(redact)),
+	}
+	for _, p := range opts.SkipPaths {
+		v.skip[p] = struct{}{}
+	}
+	for _, h := range redact {
+		v.redact[http.CanonicalHeaderKey(h)] = struct{}{}
+	}
+	return func(next http.Handler) http.Handler {
+		vv := *v
+		vv.next = next
+		return &vv
+	}
+}
+
+func (v *accessLog) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if _, ok := v.skip[r.URL.Path]; ok {
+		v.next.ServeHTTP(w, r)
+		return
+	}
+
+	id := r.Header.Get(v.opts.RequestIDHeader)
+	if len(id) == 0 {
+		id = newRequestID()
+	}
+	w.Header().Set(v.opts.RequestIDHeader, id)
+
+	var l Logger = v.log
+	if ll, ok := v.log.(*Log); ok {
+		l = ll.With("request_id", id)
+	}
+
+	rw := &responseWriter{ResponseWriter: w}
+	start := time.Now()
+	v.next.ServeHTTP(rw, r.WithContext(ContextWithLogger(r.Context(), l)))
+	duration := time.Since(start)
+
+	if rw.status == 0 {
+		rw.status = http.StatusOK
+	}
+	args := make([]interface{}, 0, 16+2*(len(v.opts.RequestHeaders)+len(v.opts.ResponseHeaders)))
+	args = append(args,
+		"method", r.Method,
+		"path", r.URL.Path,
+		"status", rw.status,
+		"size", rw.size,
+		"duration", duration,
+		"remote_addr", r.RemoteAddr,
+		"user_agent", r.UserAgent(),
+		"request_id", id,
+	)
+	args = v.headers(args, "req_header.", r.Header, v.opts.RequestHeaders)
+	args = v.headers(args, "resp_header.", rw.Header(), v.opts.ResponseHeaders)
+
+	v.log.Log(v.opts.Level(rw.status), v.opts.Message, args...)
+}
+
+func (v *accessLog) headers(args []interface{}, prefix string, h http.Header, names []string) []interface{} {
+	for _, name := range names {
+		value := h.Get(name)
+		if len(value) == 0 {
+			continue
+		}
+		if _, ok := v.redact[http.CanonicalHeaderKey(name)]; ok {
+			value = redactedValue
+		}
+		args = append(args, prefix+strings.ToLower(name), value)
+	}
+	return args
+}
+
+func newRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// responseWriter records status and size of the response
+type responseWriter struct {
+	http.ResponseWriter
+	status int
+	size   int64
+}
+
+func (v *responseWriter) WriteHeader(code int) {
+	if v.status == 0 {
+		v.status = code
+	}
+	v.ResponseWriter.WriteHeader(code)
+}
+
+func (v *responseWriter) Write(b []byte) (int, error) {
+	if v.status == 0 {
+		v.status = http.StatusOK
+	}
+	n, err := v.ResponseWriter.Write(b)
+	v.size += int64(n)
+	return n, err
+}
+
+func (v *responseWriter) Flush() {
+	if f, ok := v.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (v *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := v.ResponseWriter.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+	return nil, nil, fmt.Errorf("logx access log: %w", http.ErrNotSupported)
+}
+
+// Unwrap for http.ResponseController
+func (v *responseWriter) Unwrap() http.ResponseWriter {
+	return v.ResponseWriter
+}
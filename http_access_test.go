@@ -0,0 +1,94 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/logx"
+	"go.osspkg.com/logx/logxtest"
+)
+
+func TestUnit_AccessLog(t *testing.T) {
+	l, o := logxtest.New()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		logx.FromContext(r.Context()).Info("inside handler")
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.Write([]byte("hello")) //nolint:errcheck
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	})
+	mux.HandleFunc("/fail", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	h := logx.AccessLog(l, logx.AccessLogOptions{
+		SkipPaths:       []string{"/health"},
+		RequestHeaders:  []string{"Authorization", "X-Client"},
+		ResponseHeaders: []string{"Set-Cookie"},
+	})(mux)
+
+	call := func(path string, header map[string]string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		r.Header.Set("User-Agent", "test-agent")
+		for k, v := range header {
+			r.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	call("/health", nil)
+	casecheck.Equal(t, 0, o.Len())
+
+	w := call("/ok", map[string]string{
+		logx.HeaderRequestID: "req-1",
+		"Authorization":      "Bearer token",
+		"X-Client":           "cli",
+	})
+	casecheck.Equal(t, "req-1", w.Header().Get(logx.HeaderRequestID))
+	o.AssertLogged(t, logx.LevelInfo, "inside handler", "request_id", "req-1")
+	o.AssertLogged(t, logx.LevelInfo, "http request",
+		"method", http.MethodGet,
+		"path", "/ok",
+		"status", http.StatusOK,
+		"size", int64(5),
+		"user_agent", "test-agent",
+		"request_id", "req-1",
+		"req_header.authorization", "[REDACTED]",
+		"req_header.x-client", "cli",
+		"resp_header.set-cookie", "[REDACTED]",
+	)
+
+	w = call("/missing", nil)
+	casecheck.Equal(t, 32, len(w.Header().Get(logx.HeaderRequestID)))
+	o.AssertLogged(t, logx.LevelWarn, "http request", "status", http.StatusNotFound)
+
+	call("/fail", nil)
+	o.AssertLogged(t, logx.LevelError, "http request", "status", http.StatusBadGateway)
+	o.AssertCount(t, 4)
+}
+
+func TestUnit_FromContext(t *testing.T) {
+	l, o := logxtest.New()
+	casecheck.Equal(t, logx.Default(), logx.FromContext(context.Background()))
+
+	ctx := logx.ContextWithLogger(context.Background(), l.With("user", "u1"))
+	logx.FromContext(ctx).Info("msg", "k", "v")
+	o.AssertLogged(t, logx.LevelInfo, "msg", "user", "u1", "k", "v")
+}
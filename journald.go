@@ -0,0 +1,213 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.osspkg.com/ioutils/data"
+)
+
+// JournaldSocket default socket of the journal native protocol
+const JournaldSocket = "/run/systemd/journal/socket"
+
+// JournaldOptions settings of the journald sink, zero values use the defaults
+type JournaldOptions struct {
+	// Path of the journal socket, default JournaldSocket
+	Path string
+	// Identifier SYSLOG_IDENTIFIER field, default name of the executable
+	Identifier string
+	// Fallback is used when the socket is absent, default string format to stderr
+	Fallback Sink
+}
+
+// journaldReserved fields written by the sink itself, ctx keys with these names get the CTX_ prefix
+var journaldReserved = map[string]struct{}{
+	"MESSAGE": {}, "PRIORITY": {}, "SYSLOG_IDENTIFIER": {}, "CODE_FILE": {}, "CODE_LINE": {}, "CODE_FUNC": {},
+}
+
+// JournaldSink writes messages with the journal native protocol. Ctx keys become
+// uppercase journal fields, level is written as PRIORITY and caller as CODE_FILE, CODE_LINE and CODE_FUNC.
+// Entries too large for a datagram are passed as a memfd (Linux only).
+// The socket is dialed again when journald was restarted.
+type JournaldSink struct {
+	mux        sync.Mutex
+	path       string
+	conn       *net.UnixConn
+	enabled    bool
+	identifier string
+	fallback   Sink
+}
+
+// NewJournaldSink connect to the journal socket, without the socket messages go to the fallback
+func NewJournaldSink(opts JournaldOptions) *JournaldSink {
+	if len(opts.Path) == 0 {
+		opts.Path = JournaldSocket
+	}
+	if len(opts.Identifier) == 0 {
+		opts.Identifier = filepath.Base(os.Args[0])
+	}
+	v := &JournaldSink{path: opts.Path, identifier: opts.Identifier, fallback: opts.Fallback}
+	if err := v.dial(); err == nil {
+		v.enabled = true
+		return v
+	}
+	if v.fallback == nil {
+		v.fallback = NewSink(os.Stderr, NewFormatString())
+	}
+	return v
+}
+
+func (v *JournaldSink) dial() error {
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: v.path, Net: "unixgram"})
+	if err != nil {
+		return err
+	}
+	if v.conn != nil {
+		v.conn.Close() //nolint:errcheck
+	}
+	v.conn = conn
+	return nil
+}
+
+// Enabled true when messages are written to the journal, not to the fallback
+func (v *JournaldSink) Enabled() bool {
+	return v.enabled
+}
+
+func (v *JournaldSink) WriteMessage(m *Message) error {
+	if !v.enabled {
+		return v.fallback.WriteMessage(m)
+	}
+
+	w := poolBuffer.Get()
+	defer func() {
+		poolBuffer.Put(w)
+	}()
+	v.encode(w, m)
+
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	if v.conn == nil {
+		return fmt.Errorf("logx journald: %w", net.ErrClosed)
+	}
+	err := v.send(w.Bytes())
+	if err != nil && journaldGone(err) {
+		if err = v.dial(); err == nil {
+			err = v.send(w.Bytes())
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("logx journald: %w", err)
+	}
+	return nil
+}
+
+func (v *JournaldSink) send(b []byte) error {
+	_, err := v.conn.Write(b)
+	if err != nil && journaldTooLarge(err) {
+		err = journaldSendFd(v.conn, b)
+	}
+	return err
+}
+
+func (v *JournaldSink) encode(w *data.Buffer, m *Message) {
+	level, _ := ParseLevel(m.Level) //nolint:errcheck
+	journaldField(w, "MESSAGE", m.Message)
+	journaldField(w, "PRIORITY", strconv.Itoa(SyslogSeverity(level.Uint32())))
+	journaldField(w, "SYSLOG_IDENTIFIER", v.identifier)
+	if !m.Caller.IsZero() {
+		journaldField(w, "CODE_FILE", m.Caller.File)
+		journaldField(w, "CODE_LINE", strconv.Itoa(m.Caller.Line))
+		if len(m.Caller.Func) > 0 {
+			journaldField(w, "CODE_FUNC", m.Caller.Func)
+		}
+	}
+	for i := 0; i < len(m.Ctx); i += 2 {
+		var value interface{}
+		if i+1 < len(m.Ctx) {
+			value = m.Ctx[i+1]
+		}
+		name := journaldName(ctxString(m.Ctx[i]))
+		if len(name) == 0 {
+			continue
+		}
+		if _, ok := journaldReserved[name]; ok {
+			name = "CTX_" + name
+		}
+		journaldField(w, name, ctxString(value))
+	}
+}
+
+// journaldField NAME=value, values with new lines use the binary form NAME\n<uint64 LE size><value>
+func journaldField(w *data.Buffer, name, value string) {
+	w.WriteString(name) //nolint:errcheck
+	if strings.IndexByte(value, '\n') < 0 {
+		w.WriteByte('=')     //nolint:errcheck
+		w.WriteString(value) //nolint:errcheck
+		w.WriteByte('\n')    //nolint:errcheck
+		return
+	}
+	var size [8]byte
+	binary.LittleEndian.PutUint64(size[:], uint64(len(value)))
+	w.WriteByte('\n')    //nolint:errcheck
+	w.Write(size[:])     //nolint:errcheck
+	w.WriteString(value) //nolint:errcheck
+	w.WriteByte('\n')    //nolint:errcheck
+}
+
+// journaldName uppercase A-Z, 0-9 and '_', not starting with '_' or a digit, up to 64 chars
+func journaldName(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+	s = strings.TrimLeft(s, "_0123456789")
+	return s[:min(len(s), 64)]
+}
+
+func (v *JournaldSink) Sync() error {
+	if !v.enabled {
+		if s, ok := v.fallback.(Syncer); ok {
+			return s.Sync()
+		}
+	}
+	return nil
+}
+
+func (v *JournaldSink) Close() error {
+	if !v.enabled {
+		if c, ok := v.fallback.(io.Closer); ok {
+			return c.Close()
+		}
+		return nil
+	}
+
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	if v.conn == nil {
+		return nil
+	}
+	err := v.conn.Close()
+	v.conn = nil
+	return err
+}
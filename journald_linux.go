@@ -0,0 +1,72 @@
+//go:build linux
+
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"errors"
+	"net"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	journaldShmDir   = "/dev/shm"
+	journaldMemfdTag = "logx-journal"
+)
+
+func journaldTooLarge(err error) bool {
+	return errors.Is(err, unix.EMSGSIZE) || errors.Is(err, unix.ENOBUFS)
+}
+
+// journaldGone the socket was recreated or is not there yet, e.g. journald was restarted,
+// after a refused write the socket is left not connected
+func journaldGone(err error) bool {
+	return errors.Is(err, unix.ECONNREFUSED) || errors.Is(err, unix.ENOENT) || errors.Is(err, unix.ENOTCONN)
+}
+
+// journaldSendFd pass the entry as a sealed memfd, or as an unlinked file of /dev/shm
+// if memfd is not available, like sd_journal_send does
+func journaldSendFd(conn *net.UnixConn, b []byte) error {
+	file, err := memfdCreate(journaldMemfdTag)
+	if err != nil {
+		if file, err = os.CreateTemp(journaldShmDir, journaldMemfdTag); err != nil {
+			return err
+		}
+		os.Remove(file.Name()) //nolint:errcheck
+	}
+	defer file.Close() //nolint:errcheck
+
+	if _, err = file.Write(b); err != nil {
+		return err
+	}
+	// sealing fails for the /dev/shm file, journald accepts unlinked files without seals
+	unix.FcntlInt(file.Fd(), unix.F_ADD_SEALS, //nolint:errcheck
+		unix.F_SEAL_SEAL|unix.F_SEAL_SHRINK|unix.F_SEAL_GROW|unix.F_SEAL_WRITE)
+
+	// WriteMsgUnix rejects connected datagram sockets, so sendmsg is called directly
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return err
+	}
+	rights := unix.UnixRights(int(file.Fd()))
+	var sendErr error
+	err = raw.Write(func(fd uintptr) bool {
+		sendErr = unix.Sendmsg(int(fd), nil, rights, nil, 0)
+		return !errors.Is(sendErr, unix.EAGAIN)
+	})
+	return errors.Join(err, sendErr)
+}
+
+func memfdCreate(name string) (*os.File, error) {
+	fd, err := unix.MemfdCreate(name, unix.MFD_CLOEXEC|unix.MFD_ALLOW_SEALING)
+	if err != nil {
+		return nil, err
+	}
+	return os.NewFile(uintptr(fd), name), nil
+}
@@ -0,0 +1,25 @@
+//go:build !linux
+
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"errors"
+	"net"
+)
+
+func journaldTooLarge(error) bool {
+	return false
+}
+
+func journaldGone(error) bool {
+	return false
+}
+
+func journaldSendFd(*net.UnixConn, []byte) error {
+	return errors.ErrUnsupported
+}
@@ -0,0 +1,134 @@
+//go:build linux
+
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/logx"
+)
+
+func TestUnit_JournaldSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	casecheck.NoError(t, err)
+	defer conn.Close() //nolint:errcheck
+
+	s := logx.NewJournaldSink(logx.JournaldOptions{Path: path, Identifier: "api"})
+	casecheck.True(t, s.Enabled())
+	l := logx.New(logx.WithOutput(io.Discard), logx.WithLevel(logx.LevelInfo), logx.WithCaller(true), logx.WithSinks(s))
+
+	l.Warn("disk is full", "path", "/var", "request-id", "r1", "_trusted", "x", "multi", "a\nb", "message", "dup")
+	entry := readJournal(t, conn)
+	casecheck.Equal(t, "disk is full", entry["MESSAGE"])
+	casecheck.Equal(t, "4", entry["PRIORITY"])
+	casecheck.Equal(t, "api", entry["SYSLOG_IDENTIFIER"])
+	casecheck.Equal(t, "/var", entry["PATH"])
+	casecheck.Equal(t, "r1", entry["REQUEST_ID"])
+	casecheck.Equal(t, "x", entry["TRUSTED"])
+	casecheck.Equal(t, "a\nb", entry["MULTI"])
+	casecheck.Equal(t, "dup", entry["CTX_MESSAGE"])
+	casecheck.True(t, strings.HasSuffix(entry["CODE_FILE"], "journald_test.go"))
+	casecheck.NotEqual(t, "", entry["CODE_LINE"])
+
+	// larger than a datagram, passed as a file descriptor
+	big := strings.Repeat("x", 1<<20)
+	l.Error("big", "payload", big)
+	entry = readJournal(t, conn)
+	casecheck.Equal(t, "big", entry["MESSAGE"])
+	casecheck.Equal(t, "3", entry["PRIORITY"])
+	casecheck.Equal(t, big, entry["PAYLOAD"])
+
+	casecheck.NoError(t, l.Close())
+}
+
+func TestUnit_JournaldRedial(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.sock")
+	listen := func() *net.UnixConn {
+		conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+		casecheck.NoError(t, err)
+		return conn
+	}
+
+	conn := listen()
+	s := logx.NewJournaldSink(logx.JournaldOptions{Path: path})
+	defer s.Close() //nolint:errcheck
+	casecheck.NoError(t, s.WriteMessage(&logx.Message{Level: "INFO", Message: "before"}))
+	casecheck.Equal(t, "before", readJournal(t, conn)["MESSAGE"])
+
+	// journald restart: the socket is removed and created again
+	casecheck.NoError(t, conn.Close())
+	casecheck.NoError(t, os.Remove(path))
+	casecheck.Error(t, s.WriteMessage(&logx.Message{Level: "INFO", Message: "down"}))
+	conn = listen()
+	defer conn.Close() //nolint:errcheck
+
+	casecheck.NoError(t, s.WriteMessage(&logx.Message{Level: "INFO", Message: "after"}))
+	casecheck.Equal(t, "after", readJournal(t, conn)["MESSAGE"])
+}
+
+func TestUnit_JournaldFallback(t *testing.T) {
+	w := newMockWriter()
+	s := logx.NewJournaldSink(logx.JournaldOptions{
+		Path:     filepath.Join(t.TempDir(), "absent.sock"),
+		Fallback: logx.NewSink(w, logx.NewFormatString()),
+	})
+	casecheck.False(t, s.Enabled())
+	l := logx.New(logx.WithOutput(io.Discard), logx.WithSinks(s))
+	l.Error("to fallback")
+	casecheck.Contains(t, w.String(), "\"msg\"=\"to fallback\"")
+}
+
+func readJournal(t *testing.T, conn *net.UnixConn) map[string]string {
+	casecheck.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	buf, oob := make([]byte, 1<<16), make([]byte, 64)
+	n, oobn, _, _, err := conn.ReadMsgUnix(buf, oob)
+	casecheck.NoError(t, err)
+	b := buf[:n]
+
+	if oobn > 0 {
+		msgs, err := syscall.ParseSocketControlMessage(oob[:oobn])
+		casecheck.NoError(t, err)
+		fds, err := syscall.ParseUnixRights(&msgs[0])
+		casecheck.NoError(t, err)
+		file := os.NewFile(uintptr(fds[0]), "journal-entry")
+		defer file.Close() //nolint:errcheck
+		_, err = file.Seek(0, io.SeekStart)
+		casecheck.NoError(t, err)
+		b, err = io.ReadAll(file)
+		casecheck.NoError(t, err)
+	}
+
+	result := make(map[string]string)
+	for len(b) > 0 {
+		i := bytes.IndexAny(b, "=\n")
+		casecheck.True(t, i > 0)
+		name := string(b[:i])
+		if b[i] == '=' {
+			end := bytes.IndexByte(b, '\n')
+			result[name] = string(b[i+1 : end])
+			b = b[end+1:]
+			continue
+		}
+		size := binary.LittleEndian.Uint64(b[i+1 : i+9])
+		result[name] = string(b[i+9 : i+9+int(size)])
+		b = b[i+9+int(size)+1:]
+	}
+	return result
+}
@@ -0,0 +1,234 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Level of the log message, the value is compatible with the LevelX constants:
+//
+//	log.SetLevel(uint32(level))
+//
+// Level implements fmt.Stringer, encoding.TextMarshaler, encoding.TextUnmarshaler
+// and flag.Value, so it can be used directly in config structs and command line flags.
+type Level uint32
+
+type levelInfo struct {
+	name     string
+	short    string
+	severity uint32
+	// rank position of the level, more verbose levels have bigger ranks
+	rank int
+}
+
+// levelRankStep distance between the ranks of the built-in levels,
+// unknown values are ranked as value*levelRankStep like the built-in levels from FATAL to TRACE
+const levelRankStep = 100
+
+var (
+	levelsMux sync.Mutex
+	levels    atomic.Pointer[map[uint32]levelInfo]
+)
+
+func init() {
+	levels.Store(&map[uint32]levelInfo{
+		LevelFatal: {name: "FATAL", short: "FTL", severity: LevelFatal, rank: 0},
+		LevelPanic: {name: "PANIC", short: "PNC", severity: LevelFatal, rank: levelRankStep / 2},
+		LevelError: {name: "ERROR", short: "ERR", severity: LevelError, rank: 1 * levelRankStep},
+		LevelWarn:  {name: "WARN", short: "WRN", severity: LevelWarn, rank: 2 * levelRankStep},
+		LevelInfo:  {name: "INFO", short: "INF", severity: LevelInfo, rank: 3 * levelRankStep},
+		LevelDebug: {name: "DEBUG", short: "DBG", severity: LevelDebug, rank: 4 * levelRankStep},
+		LevelTrace: {name: "TRACE", short: "TRC", severity: LevelDebug, rank: 5 * levelRankStep},
+	})
+}
+
+var levelAliases = map[string]uint32{
+	"WARNING": LevelWarn,
+}
+
+func lookupLevel(v uint32) (levelInfo, bool) {
+	info, ok := (*levels.Load())[v]
+	return info, ok
+}
+
+func levelRank(v uint32) int {
+	if info, ok := lookupLevel(v); ok {
+		return info.rank
+	}
+	return int(v) * levelRankStep
+}
+
+// levelEnabled true when messages of level are written by a logger with threshold
+func levelEnabled(level, threshold uint32) bool {
+	return levelRank(level) <= levelRank(threshold)
+}
+
+// RegisterLevel add custom level with a free numeric value. The level is placed right above
+// its severity and below the next built-in level: NOTICE with severity LevelInfo is written
+// for LevelInfo and hidden for LevelWarn, a logger with the NOTICE level hides INFO messages.
+// Severity is one of the built-in levels from LevelFatal to LevelDebug, it is also used
+// when the level is converted for systems with a fixed set of levels (slog, syslog).
+// If short is empty, the first three letters of the name are used.
+// Registering the same level again is a no-op, see UnregisterLevel.
+func RegisterLevel(value uint32, name, short string, severity uint32) error {
+	name, short = strings.ToUpper(strings.TrimSpace(name)), strings.ToUpper(strings.TrimSpace(short))
+	if len(name) == 0 {
+		return fmt.Errorf("logx: empty level name")
+	}
+	if _, err := strconv.ParseUint(name, 10, 32); err == nil {
+		return fmt.Errorf("logx: numeric level name %q", name)
+	}
+	if len(short) == 0 {
+		short = name[:min(3, len(name))]
+	}
+	switch severity {
+	case LevelFatal, LevelError, LevelWarn, LevelInfo, LevelDebug:
+	default:
+		return fmt.Errorf("logx: invalid severity %d for level %q", severity, name)
+	}
+
+	levelsMux.Lock()
+	defer levelsMux.Unlock()
+
+	current := *levels.Load()
+	if info, ok := current[value]; ok {
+		if info.name == name && info.short == short && info.severity == severity {
+			return nil
+		}
+		return fmt.Errorf("logx: level value %d already used by %q", value, info.name)
+	}
+	if _, ok := levelAliases[name]; ok {
+		return fmt.Errorf("logx: level name %q already used", name)
+	}
+	for _, info := range current {
+		if info.name == name || info.short == name || info.name == short || info.short == short {
+			return fmt.Errorf("logx: level name %q/%q already used by %q", name, short, info.name)
+		}
+	}
+
+	next := make(map[uint32]levelInfo, len(current)+1)
+	for k, info := range current {
+		next[k] = info
+	}
+	next[value] = levelInfo{name: name, short: short, severity: severity, rank: current[severity].rank - levelRankStep/4}
+	levels.Store(&next)
+	return nil
+}
+
+// UnregisterLevel remove custom level, built-in levels are kept
+func UnregisterLevel(value uint32) {
+	levelsMux.Lock()
+	defer levelsMux.Unlock()
+
+	current := *levels.Load()
+	if _, ok := current[value]; !ok || isBuiltinLevel(value) {
+		return
+	}
+	next := make(map[uint32]levelInfo, len(current))
+	for k, info := range current {
+		if k != value {
+			next[k] = info
+		}
+	}
+	levels.Store(&next)
+}
+
+func isBuiltinLevel(v uint32) bool {
+	return v <= LevelPanic
+}
+
+// ParseLevel parse level from full name (debug), short name (DBG) or number (4), case-insensitive
+func ParseLevel(s string) (Level, error) {
+	name := strings.ToUpper(strings.TrimSpace(s))
+	if len(name) == 0 {
+		return 0, fmt.Errorf("logx: empty level")
+	}
+	for l, n := range *levels.Load() {
+		if n.name == name || n.short == name {
+			return Level(l), nil
+		}
+	}
+	if l, ok := levelAliases[name]; ok {
+		return Level(l), nil
+	}
+	if l, err := strconv.ParseUint(name, 10, 32); err == nil {
+		return Level(l), nil
+	}
+	return 0, fmt.Errorf("logx: unknown level %q", s)
+}
+
+// MustParseLevel same as ParseLevel but panics on error
+func MustParseLevel(s string) Level {
+	l, err := ParseLevel(s)
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+// String full name of the level, number for unknown levels
+func (v Level) String() string {
+	if n, ok := lookupLevel(uint32(v)); ok {
+		return n.name
+	}
+	return strconv.FormatUint(uint64(v), 10)
+}
+
+// ShortString three letter name of the level, number for unknown levels
+func (v Level) ShortString() string {
+	if n, ok := lookupLevel(uint32(v)); ok {
+		return n.short
+	}
+	return strconv.FormatUint(uint64(v), 10)
+}
+
+// Severity built-in level used for v in systems with a fixed set of levels.
+// Unknown levels are mapped to the nearest built-in level.
+func (v Level) Severity() Level {
+	if n, ok := lookupLevel(uint32(v)); ok {
+		return Level(n.severity)
+	}
+	switch rank := levelRank(uint32(v)); {
+	case rank < levelRank(LevelError):
+		return Level(LevelFatal)
+	case rank < levelRank(LevelWarn):
+		return Level(LevelError)
+	case rank < levelRank(LevelInfo):
+		return Level(LevelWarn)
+	case rank < levelRank(LevelDebug):
+		return Level(LevelInfo)
+	default:
+		return Level(LevelDebug)
+	}
+}
+
+// Uint32 value for SetLevel
+func (v Level) Uint32() uint32 {
+	return uint32(v)
+}
+
+func (v Level) MarshalText() ([]byte, error) {
+	return []byte(strings.ToLower(v.String())), nil
+}
+
+func (v *Level) UnmarshalText(b []byte) error {
+	l, err := ParseLevel(string(b))
+	if err != nil {
+		return err
+	}
+	*v = l
+	return nil
+}
+
+// Set implements flag.Value
+func (v *Level) Set(s string) error {
+	return v.UnmarshalText([]byte(s))
+}
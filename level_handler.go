@@ -0,0 +1,194 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// levelAfterFunc starts timers reverting temporary levels, replaced in tests
+var levelAfterFunc = time.AfterFunc
+
+type levelHandler struct {
+	log    *Log
+	mux    sync.Mutex
+	timers map[string]*time.Timer
+}
+
+type levelRequest struct {
+	Logger  string `json:"logger,omitempty"`
+	Level   string `json:"level,omitempty"`
+	Timeout string `json:"timeout,omitempty"`
+}
+
+type levelResponse struct {
+	Logger  string            `json:"logger,omitempty"`
+	Level   string            `json:"level"`
+	Loggers map[string]string `json:"loggers,omitempty"`
+}
+
+// LevelHandler returns http.Handler for runtime level control.
+//
+//	GET    ?logger=db                         - current level of the root or a named logger
+//	PUT    {"logger":"db.*","level":"debug"}  - change level, POST and form values are accepted too
+//	PUT    ...&timeout=10m                     - change level and revert it after the timeout
+//	DELETE ?logger=db.*                       - remove override of a named logger
+//
+// Levels are accepted by name (debug, info) or as numeric LevelX values.
+// Named levels are applied to the registry shared by l and all of its sub-loggers.
+func LevelHandler(l *Log) http.Handler {
+	return &levelHandler{
+		log:    l,
+		timers: make(map[string]*time.Timer),
+	}
+}
+
+func (v *levelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		v.get(w, r.URL.Query().Get("logger"))
+	case http.MethodPut, http.MethodPost:
+		req, err := v.decode(w, r)
+		if err != nil {
+			v.fail(w, http.StatusBadRequest, err)
+			return
+		}
+		if err = v.change(req); err != nil {
+			v.fail(w, http.StatusBadRequest, err)
+			return
+		}
+		v.get(w, req.Logger)
+	case http.MethodDelete:
+		name := r.URL.Query().Get("logger")
+		if len(name) == 0 {
+			v.fail(w, http.StatusBadRequest, fmt.Errorf("logger is required"))
+			return
+		}
+		v.mux.Lock()
+		v.stopTimer(name)
+		v.log.DeleteNamedLevel(name)
+		v.mux.Unlock()
+		v.get(w, "")
+	default:
+		w.Header().Set("Allow", "GET, PUT, POST, DELETE")
+		v.fail(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+	}
+}
+
+func (v *levelHandler) decode(w http.ResponseWriter, r *http.Request) (*levelRequest, error) {
+	req := &levelRequest{}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(req); err != nil {
+			return nil, fmt.Errorf("decode request: %w", err)
+		}
+		return req, nil
+	}
+	if err := r.ParseForm(); err != nil {
+		return nil, fmt.Errorf("decode request: %w", err)
+	}
+	req.Logger = r.Form.Get("logger")
+	req.Level = r.Form.Get("level")
+	req.Timeout = r.Form.Get("timeout")
+	return req, nil
+}
+
+func (v *levelHandler) change(req *levelRequest) error {
+	level, err := ParseLevel(req.Level)
+	if err != nil {
+		return err
+	}
+	var timeout time.Duration
+	if len(req.Timeout) > 0 {
+		if timeout, err = time.ParseDuration(req.Timeout); err != nil {
+			return fmt.Errorf("invalid timeout: %w", err)
+		}
+		if timeout <= 0 {
+			return fmt.Errorf("invalid timeout: must be positive")
+		}
+	}
+
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	v.stopTimer(req.Logger)
+
+	revert := v.snapshot(req.Logger)
+	v.set(req.Logger, uint32(level))
+
+	if timeout > 0 {
+		name := req.Logger
+		var timer *time.Timer
+		timer = levelAfterFunc(timeout, func() {
+			v.mux.Lock()
+			defer v.mux.Unlock()
+			if v.timers[name] != timer {
+				return
+			}
+			delete(v.timers, name)
+			revert()
+		})
+		v.timers[name] = timer
+	}
+	return nil
+}
+
+func (v *levelHandler) stopTimer(name string) {
+	if t, ok := v.timers[name]; ok {
+		t.Stop()
+		delete(v.timers, name)
+	}
+}
+
+func (v *levelHandler) set(name string, level uint32) {
+	if len(name) == 0 {
+		v.log.SetLevel(level)
+		return
+	}
+	v.log.SetNamedLevel(name, level)
+}
+
+func (v *levelHandler) snapshot(name string) func() {
+	if len(name) == 0 {
+		prev := v.log.GetLevel()
+		return func() { v.log.SetLevel(prev) }
+	}
+	prev, ok := v.log.NamedLevels()[name]
+	if !ok {
+		return func() { v.log.DeleteNamedLevel(name) }
+	}
+	return func() { v.log.SetNamedLevel(name, prev) }
+}
+
+func (v *levelHandler) get(w http.ResponseWriter, name string) {
+	resp := &levelResponse{Logger: name}
+	if len(name) == 0 {
+		resp.Level = Level(v.log.GetLevel()).String()
+		if named := v.log.NamedLevels(); len(named) > 0 {
+			resp.Loggers = make(map[string]string, len(named))
+			for k, l := range named {
+				resp.Loggers[k] = Level(l).String()
+			}
+		}
+	} else {
+		resp.Level = Level(v.log.Named(name).GetLevel()).String()
+	}
+	v.write(w, http.StatusOK, resp)
+}
+
+func (v *levelHandler) fail(w http.ResponseWriter, code int, err error) {
+	v.write(w, code, map[string]string{"error": err.Error()})
+}
+
+func (v *levelHandler) write(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body) //nolint:errcheck
+}
@@ -0,0 +1,76 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/logx"
+)
+
+func TestUnit_LevelHandler(t *testing.T) {
+	l := logx.New()
+	l.SetOutput(io.Discard)
+	l.SetLevel(logx.LevelInfo)
+
+	var revert func()
+	t.Cleanup(logx.SetLevelAfterFunc(func(d time.Duration, f func()) *time.Timer {
+		casecheck.Equal(t, 50*time.Millisecond, d)
+		revert = f
+		return time.NewTimer(time.Hour)
+	}))
+
+	h := logx.LevelHandler(l)
+	call := func(method, query, body string) (int, string) {
+		req := httptest.NewRequest(method, "/?"+query, strings.NewReader(body))
+		if len(body) > 0 {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code, strings.TrimSpace(rec.Body.String())
+	}
+
+	code, body := call(http.MethodGet, "", "")
+	casecheck.Equal(t, http.StatusOK, code)
+	casecheck.Equal(t, `{"level":"INFO"}`, body)
+
+	code, body = call(http.MethodPut, "", `{"logger":"db.*","level":"debug"}`)
+	casecheck.Equal(t, http.StatusOK, code)
+	casecheck.Equal(t, `{"logger":"db.*","level":"DEBUG"}`, body)
+	casecheck.Equal(t, logx.LevelDebug, l.Named("db").Named("pool").GetLevel())
+
+	code, body = call(http.MethodPost, url.Values{"level": {"2"}}.Encode(), "")
+	casecheck.Equal(t, http.StatusOK, code)
+	casecheck.Equal(t, `{"level":"WARN","loggers":{"db.*":"DEBUG"}}`, body)
+
+	code, _ = call(http.MethodPut, "", `{"level":"verbose"}`)
+	casecheck.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = call(http.MethodPatch, "", "")
+	casecheck.Equal(t, http.StatusMethodNotAllowed, code)
+
+	code, body = call(http.MethodDelete, "logger=db.*", "")
+	casecheck.Equal(t, http.StatusOK, code)
+	casecheck.Equal(t, `{"level":"WARN"}`, body)
+
+	code, _ = call(http.MethodPut, "", `{"logger":"http","level":"debug","timeout":"50ms"}`)
+	casecheck.Equal(t, http.StatusOK, code)
+	casecheck.Equal(t, logx.LevelDebug, l.Named("http").GetLevel())
+
+	casecheck.NotNil(t, revert)
+	revert()
+	casecheck.Equal(t, logx.LevelWarn, l.Named("http").GetLevel())
+	casecheck.Equal(t, 0, len(l.NamedLevels()))
+}
@@ -0,0 +1,149 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// levelRegistry keeps level overrides for named loggers.
+//
+// Patterns:
+//
+//	db      - only the logger named "db"
+//	db.*    - "db" and all of its sub-loggers ("db.pool", "db.pool.conn")
+//	*       - every named logger
+//
+// The most specific pattern wins, loggers without a match use the default level.
+type levelRegistry struct {
+	mux   sync.Mutex
+	rules atomic.Pointer[levelRules]
+}
+
+type levelRules struct {
+	items map[string]uint32
+	cache sync.Map
+}
+
+type levelMatch struct {
+	level uint32
+	ok    bool
+}
+
+func newLevelRegistry() *levelRegistry {
+	obj := &levelRegistry{}
+	obj.rules.Store(&levelRules{items: make(map[string]uint32)})
+	return obj
+}
+
+func (v *levelRegistry) update(call func(items map[string]uint32)) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	items := v.List()
+	call(items)
+	v.rules.Store(&levelRules{items: items})
+}
+
+// Set level for pattern
+func (v *levelRegistry) Set(pattern string, level uint32) {
+	v.update(func(items map[string]uint32) {
+		items[pattern] = level
+	})
+}
+
+// Delete level for pattern
+func (v *levelRegistry) Delete(pattern string) {
+	v.update(func(items map[string]uint32) {
+		delete(items, pattern)
+	})
+}
+
+// Replace all patterns
+func (v *levelRegistry) Replace(levels map[string]uint32) {
+	v.update(func(items map[string]uint32) {
+		for k := range items {
+			delete(items, k)
+		}
+		for k, l := range levels {
+			items[k] = l
+		}
+	})
+}
+
+// List copy of all patterns
+func (v *levelRegistry) List() map[string]uint32 {
+	rules := v.rules.Load()
+	result := make(map[string]uint32, len(rules.items)+1)
+	for k, l := range rules.items {
+		result[k] = l
+	}
+	return result
+}
+
+// Resolve level for logger name
+func (v *levelRegistry) Resolve(name string) (uint32, bool) {
+	rules := v.rules.Load()
+	if len(rules.items) == 0 {
+		return 0, false
+	}
+	if m, ok := rules.cache.Load(name); ok {
+		mm := m.(levelMatch)
+		return mm.level, mm.ok
+	}
+
+	m := rules.match(name)
+	rules.cache.Store(name, m)
+	return m.level, m.ok
+}
+
+func (v *levelRules) match(name string) levelMatch {
+	if l, ok := v.items[name]; ok {
+		return levelMatch{level: l, ok: true}
+	}
+	for n := name; len(n) > 0; {
+		if l, ok := v.items[n+".*"]; ok {
+			return levelMatch{level: l, ok: true}
+		}
+		i := strings.LastIndexByte(n, '.')
+		if i < 0 {
+			break
+		}
+		n = n[:i]
+	}
+	if l, ok := v.items["*"]; ok {
+		return levelMatch{level: l, ok: true}
+	}
+	return levelMatch{}
+}
+
+// parseLevelRules parses "db.*=debug,http=warn" into patterns
+func parseLevelRules(spec string) (map[string]uint32, error) {
+	result := make(map[string]uint32)
+	for _, item := range strings.FieldsFunc(spec, func(r rune) bool { return r == ',' || r == ';' }) {
+		item = strings.TrimSpace(item)
+		if len(item) == 0 {
+			continue
+		}
+		pattern, value, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("logx level rule %q: want <name>=<level>", item)
+		}
+		pattern = strings.TrimSpace(pattern)
+		if len(pattern) == 0 {
+			return nil, fmt.Errorf("logx level rule %q: empty name", item)
+		}
+		level, err := ParseLevel(value)
+		if err != nil {
+			return nil, fmt.Errorf("logx level rule %q: %w", item, err)
+		}
+		result[pattern] = uint32(level)
+	}
+	return result, nil
+}
@@ -0,0 +1,99 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx_test
+
+import (
+	"encoding/json"
+	"flag"
+	"strings"
+	"testing"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/logx"
+)
+
+func TestUnit_ParseLevel(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    uint32
+		wantErr bool
+	}{
+		{in: "debug", want: logx.LevelDebug},
+		{in: "DBG", want: logx.LevelDebug},
+		{in: " Info ", want: logx.LevelInfo},
+		{in: "inf", want: logx.LevelInfo},
+		{in: "warning", want: logx.LevelWarn},
+		{in: "error", want: logx.LevelError},
+		{in: "fatal", want: logx.LevelFatal},
+		{in: "3", want: logx.LevelInfo},
+		{in: "", wantErr: true},
+		{in: "verbose", wantErr: true},
+		{in: "-1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := logx.ParseLevel(tt.in)
+			if tt.wantErr {
+				casecheck.Error(t, err)
+				return
+			}
+			casecheck.NoError(t, err)
+			casecheck.Equal(t, tt.want, got.Uint32())
+		})
+	}
+}
+
+func TestUnit_LevelText(t *testing.T) {
+	casecheck.Equal(t, "DEBUG", logx.Level(logx.LevelDebug).String())
+	casecheck.Equal(t, "WRN", logx.Level(logx.LevelWarn).ShortString())
+	casecheck.Equal(t, "42", logx.Level(42).String())
+
+	var conf struct {
+		Level logx.Level `json:"level"`
+	}
+	casecheck.NoError(t, json.Unmarshal([]byte(`{"level":"warn"}`), &conf))
+	casecheck.Equal(t, logx.LevelWarn, conf.Level.Uint32())
+
+	b, err := json.Marshal(conf)
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, `{"level":"warn"}`, string(b))
+
+	casecheck.Error(t, json.Unmarshal([]byte(`{"level":"loud"}`), &conf))
+
+	var lvl logx.Level
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&lvl, "level", "log level")
+	casecheck.NoError(t, fs.Parse([]string{"-level", "DBG"}))
+	casecheck.Equal(t, logx.LevelDebug, lvl.Uint32())
+}
+
+func TestUnit_LevelOrder(t *testing.T) {
+	casecheck.Equal(t, []uint32{0, 1, 2, 3, 4},
+		[]uint32{logx.LevelFatal, logx.LevelError, logx.LevelWarn, logx.LevelInfo, logx.LevelDebug})
+
+	buff := newMockWriter()
+	l := logx.New()
+	l.SetOutput(buff)
+
+	l.SetLevel(4)
+	l.Debug("debug shown")
+	l.Trace("trace hidden")
+	l.SetLevel(logx.LevelFatal)
+	l.Error("error hidden")
+	l.SetLevel(logx.LevelError)
+	l.Log(logx.LevelPanic, "panic shown")
+	l.Warn("warn hidden")
+
+	data := buff.String()
+	casecheck.Contains(t, data, "debug shown")
+	casecheck.Contains(t, data, "panic shown")
+	casecheck.False(t, strings.Contains(data, "hidden"))
+
+	casecheck.Equal(t, logx.LevelFatal, logx.Level(logx.LevelPanic).Severity().Uint32())
+	casecheck.Equal(t, logx.LevelDebug, logx.Level(logx.LevelTrace).Severity().Uint32())
+	casecheck.Equal(t, logx.LevelDebug, logx.Level(42).Severity().Uint32())
+}
@@ -0,0 +1,94 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Syncer optional interface of loggers and writers with buffered output
+type Syncer interface {
+	Sync() error
+}
+
+// Flusher optional interface of writers with buffered output, like bufio.Writer
+type Flusher interface {
+	Flush() error
+}
+
+// Shutdowner optional interface of loggers supporting graceful termination
+type Shutdowner interface {
+	Shutdown(ctx context.Context) error
+}
+
+// syncWriter flushes buffered data of writers implementing Flush and/or Sync
+func syncWriter(w io.Writer) error {
+	var errs []error
+	if f, ok := w.(Flusher); ok {
+		if err := f.Flush(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if s, ok := w.(Syncer); ok && !isStdStream(w) {
+		if err := s.Sync(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// closeWriter flushes and closes writers implementing io.Closer, standard streams are not closed
+func closeWriter(w io.Writer) error {
+	err := syncWriter(w)
+	if c, ok := w.(io.Closer); ok && !isStdStream(w) {
+		err = errors.Join(err, c.Close())
+	}
+	return err
+}
+
+func isStdStream(w io.Writer) bool {
+	return w == os.Stdout || w == os.Stderr
+}
+
+func shutdown(ctx context.Context, call func() error) error {
+	result := make(chan error, 1)
+	go func() {
+		result <- call()
+	}()
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("logx shutdown: %w", ctx.Err())
+	}
+}
+
+// Sync flush buffered output of the writer and sinks
+func (l *Log) Sync() error {
+	conf := l.core.config.Load()
+	if err := errors.Join(syncWriter(conf.writer), syncSinks(conf.sinks)); err != nil {
+		return fmt.Errorf("logx sync: %w", err)
+	}
+	return nil
+}
+
+// Close flush and close the writer and sinks, standard streams are not closed
+func (l *Log) Close() error {
+	conf := l.core.config.Load()
+	if err := errors.Join(closeWriter(conf.writer), closeSinks(conf.sinks)); err != nil {
+		return fmt.Errorf("logx close: %w", err)
+	}
+	return nil
+}
+
+// Shutdown close the logger, waiting no longer than ctx allows
+func (l *Log) Shutdown(ctx context.Context) error {
+	return shutdown(ctx, l.Close)
+}
@@ -0,0 +1,74 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/logx"
+)
+
+type lifecycleWriter struct {
+	calls []string
+	block chan struct{}
+}
+
+func (v *lifecycleWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+func (v *lifecycleWriter) Flush() error {
+	v.calls = append(v.calls, "flush")
+	return nil
+}
+
+func (v *lifecycleWriter) Sync() error {
+	v.calls = append(v.calls, "sync")
+	return nil
+}
+
+func (v *lifecycleWriter) Close() error {
+	if v.block != nil {
+		<-v.block
+	}
+	v.calls = append(v.calls, "close")
+	return errors.New("closed")
+}
+
+func TestUnit_Lifecycle(t *testing.T) {
+	w := &lifecycleWriter{}
+
+	l := logx.New()
+	l.SetOutput(w)
+
+	casecheck.NoError(t, l.Sync())
+	casecheck.Equal(t, []string{"flush", "sync"}, w.calls)
+
+	w.calls = w.calls[:0]
+	casecheck.Error(t, l.Close())
+	casecheck.Equal(t, []string{"flush", "sync", "close"}, w.calls)
+
+	w.block = make(chan struct{})
+	defer close(w.block)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := l.Shutdown(ctx)
+	casecheck.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	var (
+		_ logx.Syncer     = l
+		_ io.Closer       = l
+		_ logx.Shutdowner = l
+		_ logx.Syncer     = logx.NewSLogJsonAdapter().(logx.Syncer)
+	)
+}
@@ -6,31 +6,127 @@
 package logx
 
 import (
-	"fmt"
 	"io"
 	"os"
+	"sync"
 	"sync/atomic"
 	"time"
 )
 
 // Log base model
 type Log struct {
-	level     uint32
-	writer    io.Writer
-	formatter Formatter
+	name   string
+	fields []interface{}
+	core   *logCore
 }
 
-// New init new logger
-func New() *Log {
-	return &Log{
-		level:     LevelError,
-		writer:    os.Stdout,
-		formatter: NewFormatJSON(),
+// logCore state shared by the root logger and all named sub-loggers
+type logCore struct {
+	mux      sync.Mutex
+	config   atomic.Pointer[config]
+	levels   *levelRegistry
+	exit     *exitState
+	failures atomic.Uint64
+	// retired superseded configs which may still have in-flight writes
+	retired []*config
+}
+
+// New init new logger, without options it writes JSON to stdout with LevelError.
+// New panics if any option is invalid, e.g. nil output.
+func New(opts ...Option) *Log {
+	c := &logCore{
+		levels: newLevelRegistry(),
+		exit:   newExitState(),
+	}
+	c.config.Store(&config{
+		level:        LevelError,
+		writer:       os.Stdout,
+		formatter:    NewFormatJSON(),
+		errorHandler: DefaultErrorHandler,
+		clock:        time.Now,
+		inflight:     &atomic.Int64{},
+	})
+	if err := c.update(opts...); err != nil {
+		panic(err)
+	}
+	return &Log{core: c}
+}
+
+func (c *logCore) update(opts ...Option) error {
+	_, err := c.swap(opts...)
+	return err
+}
+
+// swap applies options to a copy of the current config and returns the replaced one
+func (c *logCore) swap(opts ...Option) (*config, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	prev := c.config.Load()
+	next := *prev
+	for _, opt := range opts {
+		if err := opt(&next); err != nil {
+			return nil, err
+		}
+	}
+	if next.namedLevels != nil {
+		c.levels.Replace(next.namedLevels)
+		next.namedLevels = nil
+	}
+	next.inflight = &atomic.Int64{}
+	c.config.Store(&next)
+
+	retired := c.retired[:0]
+	for _, conf := range c.retired {
+		if conf.inflight.Load() > 0 {
+			retired = append(retired, conf)
+		}
+	}
+	c.retired = append(retired, prev)
+	return prev, nil
+}
+
+// drain waits until writes of all superseded configs are finished or the deadline is reached
+func (c *logCore) drain(deadline time.Time) {
+	c.mux.Lock()
+	retired := append([]*config(nil), c.retired...)
+	c.mux.Unlock()
+
+	for _, conf := range retired {
+		for conf.inflight.Load() > 0 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
 	}
 }
 
+// acquire current config for writing, the config must be released after use
+func (c *logCore) acquire() *config {
+	for {
+		conf := c.config.Load()
+		conf.inflight.Add(1)
+		if c.config.Load() == conf {
+			return conf
+		}
+		conf.inflight.Add(-1)
+	}
+}
+
+func (l *Log) level(conf *config) uint32 {
+	if len(l.name) > 0 {
+		if v, ok := l.core.levels.Resolve(l.name); ok {
+			return v
+		}
+	}
+	return conf.level
+}
+
 func (l *Log) writeMessage(level uint32, call func(v *Message)) {
-	if l.GetLevel() < level {
+	if !levelEnabled(level, l.GetLevel()) {
+		return
+	}
+	conf := l.core.acquire()
+	defer conf.inflight.Add(-1)
+	if !levelEnabled(level, l.level(conf)) {
 		return
 	}
 
@@ -39,38 +135,117 @@ func (l *Log) writeMessage(level uint32, call func(v *Message)) {
 		poolMessage.Put(m)
 	}()
 
+	if len(l.name) > 0 {
+		m.Ctx = append(m.Ctx, "logger", l.name)
+	}
+	m.Ctx = append(m.Ctx, conf.fields...)
+	m.Ctx = append(m.Ctx, l.fields...)
 	call(m)
 
-	lvl, ok := levels[level]
-	if !ok {
-		lvl = "UNK"
+	m.Time = conf.clock()
+	if conf.sampler != nil && Level(level).Severity() != Level(LevelFatal) && !conf.sampler.Allow(level, m.Message, m.Time) {
+		return
+	}
+	m.Level = Level(level).String()
+	if conf.caller {
+		m.Caller = findCaller()
+	}
+	for _, hook := range conf.hooks {
+		hook(m)
 	}
-	m.Level, m.Time = lvl, time.Now()
 
-	err := l.formatter.Encode(l.writer, m)
-	if err != nil {
-		fmt.Println(err)
+	l.encode(conf, m)
+}
+
+// Named returns a sub-logger which adds the logger=<name> field to every message.
+// Sub-loggers share output and formatter with the parent, nested names are joined with a dot.
+func (l *Log) Named(name string) *Log {
+	if len(l.name) > 0 {
+		name = l.name + "." + name
+	}
+	return &Log{name: name, fields: l.fields, core: l.core}
+}
+
+// With returns a sub-logger which adds key-value pairs to every message,
+// e.g. l.With("request_id", id). An odd last key gets a nil value.
+func (l *Log) With(args ...interface{}) *Log {
+	fields := make([]interface{}, 0, len(l.fields)+len(args)+1)
+	fields = append(fields, l.fields...)
+	fields = append(fields, args...)
+	if len(args)%2 != 0 {
+		fields = append(fields, nil)
 	}
+	return &Log{name: l.name, fields: fields, core: l.core}
+}
 
+// Name of the logger, empty for the root logger
+func (l *Log) Name() string {
+	return l.name
 }
 
-// SetOutput change writer
+// Reconfigure applies all options together, concurrent writes use either
+// the previous or the new configuration, never a mix of them.
+// On error no option is applied.
+func (l *Log) Reconfigure(opts ...Option) error {
+	return l.core.update(opts...)
+}
+
+// SetOutput change writer. Nil keeps the current writer and is reported to the error handler.
 func (l *Log) SetOutput(out io.Writer) {
-	l.writer = out
+	l.set(WithOutput(out))
 }
 
+// SetFormatter change formatter. Nil keeps the current formatter and is reported to the error handler.
 func (l *Log) SetFormatter(f Formatter) {
-	l.formatter = f
+	l.set(WithFormatter(f))
 }
 
-// SetLevel change Log level
+// set applies option of a setter, setters have no error result so a rejected option goes to the error handler
+func (l *Log) set(opt Option) {
+	if err := l.core.update(opt); err != nil {
+		l.core.config.Load().errorHandler(err)
+	}
+}
+
+// SetLevel change Log level. For the root logger it changes the default level,
+// for a named logger it sets the level override for its name.
 func (l *Log) SetLevel(v uint32) {
-	atomic.StoreUint32(&l.level, v)
+	if len(l.name) > 0 {
+		l.core.levels.Set(l.name, v)
+		return
+	}
+	l.core.update(WithLevel(v)) //nolint:errcheck
 }
 
 // GetLevel getting Log level
 func (l *Log) GetLevel() uint32 {
-	return atomic.LoadUint32(&l.level)
+	return l.level(l.core.config.Load())
+}
+
+// SetNamedLevel set level override for named loggers matched by pattern:
+// "db" - exact name, "db.*" - name with all sub-loggers, "*" - all named loggers.
+func (l *Log) SetNamedLevel(pattern string, v uint32) {
+	l.core.levels.Set(pattern, v)
+}
+
+// DeleteNamedLevel remove level override, matched loggers fall back to the default level
+func (l *Log) DeleteNamedLevel(pattern string) {
+	l.core.levels.Delete(pattern)
+}
+
+// NamedLevels copy of all level overrides
+func (l *Log) NamedLevels() map[string]uint32 {
+	return l.core.levels.List()
+}
+
+// SetNamedLevels replace all level overrides from spec like "db.*=debug,http=warn"
+func (l *Log) SetNamedLevels(spec string) error {
+	rules, err := parseLevelRules(spec)
+	if err != nil {
+		return err
+	}
+	l.core.levels.Replace(rules)
+	return nil
 }
 
 func (l *Log) Info(message string, args ...interface{}) {
@@ -101,10 +276,35 @@ func (l *Log) Debug(message string, args ...interface{}) {
 	})
 }
 
+func (l *Log) Trace(message string, args ...interface{}) {
+	l.writeMessage(LevelTrace, func(v *Message) {
+		v.Message = message
+		v.Ctx = append(v.Ctx, args...)
+	})
+}
+
+// Log message with any built-in or custom level, see RegisterLevel
+func (l *Log) Log(level uint32, message string, args ...interface{}) {
+	l.writeMessage(level, func(v *Message) {
+		v.Message = message
+		v.Ctx = append(v.Ctx, args...)
+	})
+}
+
+// Panic writes message and panics with it, deferred functions and recover work as usual
+func (l *Log) Panic(message string, args ...interface{}) {
+	l.writeMessage(LevelPanic, func(v *Message) {
+		v.Message = message
+		v.Ctx = append(v.Ctx, args...)
+	})
+	panic(message)
+}
+
+// Fatal writes message, runs exit hooks, flushes output and calls exit function, see SetExitFunc
 func (l *Log) Fatal(message string, args ...interface{}) {
-	l.writeMessage(levelFatal, func(v *Message) {
+	l.writeMessage(LevelFatal, func(v *Message) {
 		v.Message = message
 		v.Ctx = append(v.Ctx, args...)
 	})
-	os.Exit(1)
+	l.exit()
 }
@@ -10,8 +10,12 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"go.osspkg.com/casecheck"
 	"go.osspkg.com/syncing"
@@ -180,6 +184,226 @@ func TestUnit_NewSlog(t *testing.T) {
 	casecheck.Contains(t, data, "\"level\":\"INFO\",\"msg\":\"context5\",\"obj\":{\"A\":\"\",\"B\":0}")
 }
 
+func TestUnit_Named(t *testing.T) {
+	buff := newMockWriter()
+
+	l := logx.New()
+	l.SetFormatter(logx.NewFormatString())
+	l.SetOutput(buff)
+	l.SetLevel(logx.LevelInfo)
+
+	db := l.Named("db")
+	pool := db.Named("pool")
+	http := l.Named("http")
+	casecheck.Equal(t, "db.pool", pool.Name())
+
+	casecheck.NoError(t, l.SetNamedLevels("db.*=debug, http=warn"))
+	casecheck.Equal(t, logx.LevelDebug, db.GetLevel())
+	casecheck.Equal(t, logx.LevelDebug, pool.GetLevel())
+	casecheck.Equal(t, logx.LevelWarn, http.GetLevel())
+	casecheck.Equal(t, logx.LevelInfo, l.Named("cache").GetLevel())
+
+	pool.Debug("conn", "id", 1)
+	http.Info("request", "id", 2)
+	http.Warn("slow", "id", 3)
+	l.Debug("root", "id", 4)
+
+	pool.SetLevel(logx.LevelError)
+	pool.Debug("conn", "id", 5)
+	db.Debug("query", "id", 6)
+
+	l.DeleteNamedLevel("db.*")
+	db.Debug("query", "id", 7)
+
+	data := buff.String()
+	casecheck.Contains(t, data, "\"msg\"=\"conn\"\t\"logger\"=\"db.pool\"\t\"id\"=\"1\"")
+	casecheck.Contains(t, data, "\"msg\"=\"slow\"\t\"logger\"=\"http\"\t\"id\"=\"3\"")
+	casecheck.Contains(t, data, "\"msg\"=\"query\"\t\"logger\"=\"db\"\t\"id\"=\"6\"")
+	for _, id := range []string{"2", "4", "5", "7"} {
+		casecheck.False(t, strings.Contains(data, "\"id\"=\""+id+"\""), id)
+	}
+
+	casecheck.Error(t, l.SetNamedLevels("db=verbose"))
+	casecheck.Error(t, l.SetNamedLevels("db"))
+}
+
+func TestUnit_CustomLevel(t *testing.T) {
+	const levelNotice uint32 = 25
+	casecheck.NoError(t, logx.RegisterLevel(levelNotice, "notice", "", logx.LevelInfo))
+	t.Cleanup(func() { logx.UnregisterLevel(levelNotice) })
+	casecheck.NoError(t, logx.RegisterLevel(levelNotice, "notice", "", logx.LevelInfo))
+	casecheck.Error(t, logx.RegisterLevel(levelNotice, "audit", "", logx.LevelInfo))
+	casecheck.Error(t, logx.RegisterLevel(26, "NOTICE", "", logx.LevelInfo))
+	casecheck.Error(t, logx.RegisterLevel(27, "verbose", "", 27))
+	casecheck.Equal(t, "NOT", logx.Level(levelNotice).ShortString())
+	casecheck.Equal(t, logx.LevelInfo, logx.Level(levelNotice).Severity().Uint32())
+
+	lvl, err := logx.ParseLevel("notice")
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, levelNotice, lvl.Uint32())
+
+	logx.UnregisterLevel(logx.LevelInfo)
+	casecheck.Equal(t, "INFO", logx.Level(logx.LevelInfo).String())
+
+	for _, l := range []logx.Logger{logx.New(), logx.NewSLogJsonAdapter()} {
+		buff := newMockWriter()
+		l.SetOutput(buff)
+
+		l.SetLevel(logx.LevelWarn)
+		l.Log(levelNotice, "hidden")
+		l.SetLevel(logx.LevelInfo)
+		l.Log(levelNotice, "audit", "user", "root")
+		l.Trace("hidden")
+		l.SetLevel(logx.LevelTrace)
+		l.Trace("dump", "len", 3)
+
+		data := buff.String()
+		casecheck.False(t, strings.Contains(data, "hidden"))
+		casecheck.Contains(t, data, `"level":"NOTICE","msg":"audit"`)
+		casecheck.Contains(t, data, `"level":"TRACE","msg":"dump"`)
+	}
+}
+
+func TestUnit_FatalPanic(t *testing.T) {
+	buff := newMockWriter()
+
+	l := logx.New()
+	l.SetFormatter(logx.NewFormatString())
+	l.SetOutput(buff)
+
+	var calls []string
+	l.SetExitCode(3)
+	l.SetExitFunc(func(code int) { calls = append(calls, fmt.Sprintf("exit %d", code)) })
+	l.OnExit(func() { calls = append(calls, "hook 1") })
+	l.OnExit(func() { panic("broken hook") })
+	l.OnExit(func() { calls = append(calls, "hook 3") })
+
+	l.Fatal("fatal", "id", 1)
+	casecheck.Equal(t, []string{"hook 3", "hook 1", "exit 3"}, calls)
+	casecheck.Contains(t, buff.String(), "\"level\"=\"FATAL\"\t\"msg\"=\"fatal\"\t\"id\"=\"1\"")
+
+	func() {
+		defer func() {
+			casecheck.Equal(t, "panic", recover())
+		}()
+		l.Panic("panic", "id", 2)
+	}()
+	casecheck.Contains(t, buff.String(), "\"level\"=\"PANIC\"\t\"msg\"=\"panic\"\t\"id\"=\"2\"")
+}
+
+func TestUnit_FatalKeepsOutput(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "app.log")
+	out, err := os.Create(filename)
+	casecheck.NoError(t, err)
+
+	l := logx.New()
+	l.SetOutput(out)
+	l.SetExitFunc(func(int) {})
+	defer l.Close() //nolint:errcheck
+
+	l.Fatal("fatal")
+	l.Error("after exit")
+
+	b, err := os.ReadFile(filename)
+	casecheck.NoError(t, err)
+	casecheck.Contains(t, string(b), `"msg":"after exit"`)
+}
+
+func TestUnit_Reconfigure(t *testing.T) {
+	wg := syncing.NewGroup(context.TODO())
+	buff1, buff2 := newMockWriter(), newMockWriter()
+
+	l := logx.New()
+	l.SetOutput(buff1)
+	l.SetLevel(logx.LevelInfo)
+
+	for i := 0; i < 4; i++ {
+		wg.Background("", func(_ context.Context) {
+			for j := 0; j < 100; j++ {
+				l.Info("async", "id", j)
+			}
+		})
+	}
+	wg.Background("", func(_ context.Context) {
+		for j := 0; j < 100; j++ {
+			l.SetFormatter(logx.NewFormatString())
+			l.SetOutput(buff2)
+			l.SetFormatter(logx.NewFormatJSON())
+			l.SetOutput(buff1)
+		}
+	})
+	wg.Wait()
+
+	err := l.Reconfigure(
+		logx.WithLevel(logx.LevelDebug),
+		logx.WithOutput(buff2),
+		logx.WithFormatter(nil),
+	)
+	casecheck.Error(t, err)
+	casecheck.Equal(t, logx.LevelInfo, l.GetLevel())
+
+	err = l.Reconfigure(
+		logx.WithLevel(logx.LevelDebug),
+		logx.WithOutput(buff2),
+		logx.WithFormatter(logx.NewFormatString()),
+	)
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, logx.LevelDebug, l.GetLevel())
+
+	l.Debug("reconfigured")
+	casecheck.Contains(t, buff2.String(), "\"level\"=\"DEBUG\"\t\"msg\"=\"reconfigured\"")
+}
+
+func TestUnit_NewOptions(t *testing.T) {
+	buff := newMockWriter()
+	ts := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	l := logx.New(
+		logx.WithLevel(logx.LevelInfo),
+		logx.WithOutput(buff),
+		logx.WithFormatter(logx.NewFormatJSON()),
+		logx.WithCaller(true),
+		logx.WithFields("app", "demo"),
+		logx.WithClock(func() time.Time { return ts }),
+		logx.WithHooks(func(m *logx.Message) {
+			m.Ctx = append(m.Ctx, "hook", m.Level)
+		}),
+	)
+	casecheck.Equal(t, logx.LevelInfo, l.GetLevel())
+
+	l.Info("options", "id", 1)
+	prev := logx.Default()
+	logx.SetDefault(l)
+	logx.Info("default")
+	logx.SetDefault(prev)
+	l.Debug("hidden")
+
+	data := buff.String()
+	casecheck.Contains(t, data, `{"time":"2026-01-02T03:04:05Z","level":"INFO","msg":"options","caller":"`)
+	casecheck.Contains(t, data, `logger_test.go:`)
+	casecheck.Contains(t, data, `"app":"demo"`)
+	casecheck.Contains(t, data, `"hook":"INFO"`)
+	casecheck.Contains(t, data, `"id":"1"`)
+	casecheck.Contains(t, data, `"msg":"default","caller":"`)
+	casecheck.False(t, strings.Contains(data, "hidden"))
+	casecheck.False(t, strings.Contains(data, "default.go"))
+
+	for _, opt := range []logx.Option{
+		logx.WithOutput(nil),
+		logx.WithFormatter(nil),
+		logx.WithFields("odd"),
+		logx.WithHooks(nil),
+		logx.WithClock(nil),
+	} {
+		func() {
+			defer func() {
+				casecheck.NotNil(t, recover())
+			}()
+			logx.New(opt)
+		}()
+	}
+}
+
 /*
 goos: linux
 goarch: amd64
@@ -252,3 +476,18 @@ func BenchmarkNewSLogStringAdapter(b *testing.B) {
 		}
 	})
 }
+
+func TestUnit_SetNilOutput(t *testing.T) {
+	var errs []error
+	buff := newMockWriter()
+	l := logx.New(logx.WithOutput(buff), logx.WithErrorHandler(func(err error) { errs = append(errs, err) }))
+
+	l.SetOutput(nil)
+	l.SetFormatter(nil)
+	l.Error("kept")
+
+	casecheck.Contains(t, buff.String(), `"msg":"kept"`)
+	casecheck.Equal(t, 2, len(errs))
+	casecheck.Contains(t, errs[0].Error(), "nil output")
+	casecheck.Contains(t, errs[1].Error(), "nil formatter")
+}
@@ -0,0 +1,95 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logxtest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Entries list of recorded messages
+type Entries []Entry
+
+// Len count of entries
+func (v Entries) Len() int {
+	return len(v)
+}
+
+// Filter entries matched by call
+func (v Entries) Filter(call func(e Entry) bool) Entries {
+	result := make(Entries, 0, len(v))
+	for _, e := range v {
+		if call(e) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// FilterLevel entries with level
+func (v Entries) FilterLevel(level uint32) Entries {
+	return v.Filter(func(e Entry) bool {
+		return e.Level.Uint32() == level
+	})
+}
+
+// FilterMessage entries with message
+func (v Entries) FilterMessage(message string) Entries {
+	return v.Filter(func(e Entry) bool {
+		return e.Message == message
+	})
+}
+
+// FilterField entries with field key equal to value
+func (v Entries) FilterField(key string, value interface{}) Entries {
+	return v.Filter(func(e Entry) bool {
+		got, ok := e.Field(key)
+		return ok && reflect.DeepEqual(got, value)
+	})
+}
+
+// Messages texts of entries
+func (v Entries) Messages() []string {
+	result := make([]string, 0, len(v))
+	for _, e := range v {
+		result = append(result, e.Message)
+	}
+	return result
+}
+
+func (v Entries) String() string {
+	var b strings.Builder
+	for _, e := range v {
+		b.WriteString(e.Level.String())
+		b.WriteByte(' ')
+		b.WriteString(fmt.Sprintf("%q", e.Message))
+		for _, f := range e.Fields {
+			b.WriteString(fmt.Sprintf(" %s=%v", f.Key, f.Value))
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func (v Entries) match(level uint32, message string, fields []interface{}) Entries {
+	result := v.FilterLevel(level).FilterMessage(message)
+	for i := 0; i < len(fields); i += 2 {
+		var value interface{}
+		if i+1 < len(fields) {
+			value = fields[i+1]
+		}
+		result = result.FilterField(toString(fields[i]), value)
+	}
+	return result
+}
+
+func toString(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,187 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+// Package logxtest provides loggers for tests which record messages for assertions.
+package logxtest
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.osspkg.com/logx"
+)
+
+// Field key-value pair of the message context
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// Entry copy of a message written by the logger
+type Entry struct {
+	Time    time.Time
+	Level   logx.Level
+	Message string
+	Caller  logx.Caller
+	Fields  []Field
+}
+
+// Field value by key
+func (v Entry) Field(key string) (interface{}, bool) {
+	for _, f := range v.Fields {
+		if f.Key == key {
+			return f.Value, true
+		}
+	}
+	return nil, false
+}
+
+// Observer records copies of all messages, implements logx.Formatter
+type Observer struct {
+	mux       sync.Mutex
+	entries   Entries
+	formatter logx.Formatter
+}
+
+var _ logx.Formatter = (*Observer)(nil)
+
+// New logger with TRACE level recording all messages to the observer, output is discarded.
+// Options are applied after the defaults and can change level or add fields.
+func New(opts ...logx.Option) (*logx.Log, *Observer) {
+	return newLogger(io.Discard, nil, opts)
+}
+
+// NewT same as New, messages are also written to t.Log as strings,
+// so they are shown only for failed tests or with -v.
+func NewT(t testing.TB, opts ...logx.Option) (*logx.Log, *Observer) {
+	return newLogger(newTestWriter(t), logx.NewFormatString(), opts)
+}
+
+func newLogger(w io.Writer, f logx.Formatter, opts []logx.Option) (*logx.Log, *Observer) {
+	o := &Observer{formatter: f}
+	l := logx.New(append([]logx.Option{
+		logx.WithLevel(logx.LevelTrace),
+		logx.WithOutput(w),
+		logx.WithFormatter(o),
+	}, opts...)...)
+	return l, o
+}
+
+func (v *Observer) Encode(w io.Writer, m *logx.Message) error {
+	level, _ := logx.ParseLevel(m.Level)
+	e := Entry{
+		Time:    m.Time,
+		Level:   level,
+		Message: m.Message,
+		Caller:  m.Caller,
+		Fields:  make([]Field, 0, (len(m.Ctx)+1)/2),
+	}
+	for i := 0; i < len(m.Ctx); i += 2 {
+		f := Field{Key: toString(m.Ctx[i])}
+		if i+1 < len(m.Ctx) {
+			f.Value = m.Ctx[i+1]
+		}
+		e.Fields = append(e.Fields, f)
+	}
+
+	v.mux.Lock()
+	v.entries = append(v.entries, e)
+	v.mux.Unlock()
+
+	if v.formatter == nil {
+		return nil
+	}
+	return v.formatter.Encode(w, m)
+}
+
+// All copy of recorded entries
+func (v *Observer) All() Entries {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+	return append(make(Entries, 0, len(v.entries)), v.entries...)
+}
+
+// Len count of recorded entries
+func (v *Observer) Len() int {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+	return len(v.entries)
+}
+
+// Reset remove all recorded entries
+func (v *Observer) Reset() {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+	v.entries = nil
+}
+
+// FilterLevel entries with level
+func (v *Observer) FilterLevel(level uint32) Entries {
+	return v.All().FilterLevel(level)
+}
+
+// FilterMessage entries with message
+func (v *Observer) FilterMessage(message string) Entries {
+	return v.All().FilterMessage(message)
+}
+
+// FilterField entries with field key equal to value
+func (v *Observer) FilterField(key string, value interface{}) Entries {
+	return v.All().FilterField(key, value)
+}
+
+// AssertLogged fails the test if there is no entry with the level, message and fields
+func (v *Observer) AssertLogged(t testing.TB, level uint32, message string, fields ...interface{}) {
+	t.Helper()
+	if v.All().match(level, message, fields).Len() == 0 {
+		t.Errorf("logxtest: message %s %q %v not logged, got:\n%s", logx.Level(level), message, fields, v.All())
+	}
+}
+
+// AssertNotLogged fails the test if there is an entry with the level, message and fields
+func (v *Observer) AssertNotLogged(t testing.TB, level uint32, message string, fields ...interface{}) {
+	t.Helper()
+	if found := v.All().match(level, message, fields); found.Len() > 0 {
+		t.Errorf("logxtest: message %s %q %v logged:\n%s", logx.Level(level), message, fields, found)
+	}
+}
+
+// AssertCount fails the test if count of entries is not n
+func (v *Observer) AssertCount(t testing.TB, n int) {
+	t.Helper()
+	if all := v.All(); all.Len() != n {
+		t.Errorf("logxtest: want %d messages, got %d:\n%s", n, all.Len(), all)
+	}
+}
+
+type testWriter struct {
+	t    testing.TB
+	mux  sync.Mutex
+	done bool
+}
+
+func newTestWriter(t testing.TB) *testWriter {
+	w := &testWriter{t: t}
+	t.Cleanup(func() {
+		w.mux.Lock()
+		defer w.mux.Unlock()
+		w.done = true
+	})
+	return w
+}
+
+func (v *testWriter) Write(b []byte) (int, error) {
+	// t.Log panics after the test is finished, late messages of goroutines are dropped.
+	// The lock keeps the test from finishing between the check and the call.
+	v.mux.Lock()
+	defer v.mux.Unlock()
+	if !v.done {
+		v.t.Log(strings.TrimSuffix(string(b), "\n"))
+	}
+	return len(b), nil
+}
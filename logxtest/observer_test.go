@@ -0,0 +1,71 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logxtest_test
+
+import (
+	"testing"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/logx"
+	"go.osspkg.com/logx/logxtest"
+)
+
+func TestUnit_Observer(t *testing.T) {
+	l, o := logxtest.New(logx.WithFields("app", "demo"))
+
+	l.Info("start", "port", 8080)
+	l.Named("db").Debug("query", "table", "users")
+	l.Trace("wire", "len", 3)
+	l.Error("failed", "port", 8080)
+
+	o.AssertCount(t, 4)
+	o.AssertLogged(t, logx.LevelInfo, "start", "port", 8080, "app", "demo")
+	o.AssertLogged(t, logx.LevelDebug, "query", "logger", "db")
+	o.AssertNotLogged(t, logx.LevelDebug, "start")
+
+	casecheck.Equal(t, 1, o.FilterLevel(logx.LevelTrace).Len())
+	casecheck.Equal(t, []string{"start", "failed"}, o.FilterField("port", 8080).Messages())
+	casecheck.Equal(t, 0, o.FilterField("port", "8080").Len())
+	casecheck.Equal(t, 1, o.FilterMessage("failed").FilterLevel(logx.LevelError).Len())
+
+	e := o.All()[1]
+	casecheck.Equal(t, []logxtest.Field{
+		{Key: "logger", Value: "db"},
+		{Key: "app", Value: "demo"},
+		{Key: "table", Value: "users"},
+	}, e.Fields)
+
+	o.Reset()
+	casecheck.Equal(t, 0, o.Len())
+}
+
+func TestUnit_ObserverAssertFail(t *testing.T) {
+	l, o := logxtest.New()
+	l.Info("start")
+
+	mock := &mockTB{TB: t}
+	o.AssertLogged(mock, logx.LevelInfo, "stop")
+	o.AssertNotLogged(mock, logx.LevelInfo, "start")
+	o.AssertCount(mock, 2)
+	casecheck.Equal(t, 3, mock.errors)
+}
+
+func TestUnit_NewT(t *testing.T) {
+	l, o := logxtest.NewT(t, logx.WithLevel(logx.LevelInfo))
+	l.Info("visible with -v", "id", 1)
+	l.Debug("hidden")
+	o.AssertCount(t, 1)
+}
+
+type mockTB struct {
+	testing.TB
+	errors int
+}
+
+func (v *mockTB) Errorf(string, ...interface{}) {
+	v.errors++
+}
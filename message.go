@@ -6,6 +6,10 @@
 package logx
 
 import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"go.osspkg.com/ioutils/pool"
@@ -22,6 +26,7 @@ type Message struct {
 	Time    time.Time         `json:"time" yaml:"time"`
 	Level   string            `json:"level" yaml:"level"`
 	Message string            `json:"msg" yaml:"msg"`
+	Caller  Caller            `json:"caller,omitempty" yaml:"caller,omitempty"`
 	Ctx     []interface{}     `json:"-"`
 	Map     map[string]string `json:"ctx,omitempty" yaml:"ctx,omitempty,inline"`
 }
@@ -34,12 +39,31 @@ func newMessage() *Message {
 }
 
 func (v *Message) Reset() {
+	v.Caller = Caller{}
 	v.Ctx = v.Ctx[:0]
 	for k := range v.Map {
 		delete(v.Map, k)
 	}
 }
 
+// Clone deep copy of the message for sinks keeping it after the call, the copy is not pooled
+func (v *Message) Clone() *Message {
+	c := &Message{
+		Time:    v.Time,
+		Level:   v.Level,
+		Message: v.Message,
+		Caller:  v.Caller,
+		Ctx:     make([]interface{}, len(v.Ctx)),
+		Map:     make(map[string]string, len(v.Map)),
+	}
+	copy(c.Ctx, v.Ctx)
+	for k, val := range v.Map {
+		c.Map[k] = val
+	}
+	return c
+}
+
+// CtxToMap fills Map from Ctx pairs, Ctx is kept so the message can be encoded by several formatters
 func (v *Message) CtxToMap() {
 	count := len(v.Ctx)
 	if count == 0 {
@@ -52,5 +76,59 @@ func (v *Message) CtxToMap() {
 	for i := 0; i < count; i = i + 2 {
 		v.Map[typing(v.Ctx[i])] = typing(v.Ctx[i+1])
 	}
-	v.Ctx = v.Ctx[:0]
+}
+
+// ctxString ctx key or value as text, strings are returned as is for formats doing their own escaping
+func ctxString(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return typing(v)
+}
+
+// Caller source code location of the log call, see WithCaller
+type Caller struct {
+	File string
+	Line int
+	Func string
+}
+
+func (v Caller) IsZero() bool {
+	return v.Line == 0 && len(v.File) == 0
+}
+
+// IsDefined implements easyjson.Optional for omitempty
+func (v Caller) IsDefined() bool {
+	return !v.IsZero()
+}
+
+// String short form: package dir/file.go:line
+func (v Caller) String() string {
+	if v.IsZero() {
+		return ""
+	}
+	dir, file := filepath.Split(v.File)
+	return filepath.Join(filepath.Base(dir), file) + ":" + strconv.Itoa(v.Line)
+}
+
+func (v Caller) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v *Caller) UnmarshalText(b []byte) error {
+	*v = Caller{}
+	if len(b) == 0 {
+		return nil
+	}
+	s := string(b)
+	i := strings.LastIndexByte(s, ':')
+	if i < 0 {
+		return fmt.Errorf("logx: invalid caller %q", s)
+	}
+	line, err := strconv.Atoi(s[i+1:])
+	if err != nil {
+		return fmt.Errorf("logx: invalid caller %q: %w", s, err)
+	}
+	v.File, v.Line = s[:i], line
+	return nil
 }
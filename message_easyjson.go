@@ -52,6 +52,14 @@ func easyjson4086215fDecodeGoOsspkgComLogx(in *jlexer.Lexer, out *Message) {
 			} else {
 				out.Message = string(in.String())
 			}
+		case "caller":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((out.Caller).UnmarshalText(data))
+				}
+			}
 		case "ctx":
 			if in.IsNull() {
 				in.Skip()
@@ -105,6 +113,11 @@ func easyjson4086215fEncodeGoOsspkgComLogx(out *jwriter.Writer, in Message) {
 		out.RawString(prefix)
 		out.String(string(in.Message))
 	}
+	if (in.Caller).IsDefined() {
+		const prefix string = ",\"caller\":"
+		out.RawString(prefix)
+		out.RawText((in.Caller).MarshalText())
+	}
 	if len(in.Map) != 0 {
 		const prefix string = ",\"ctx\":"
 		out.RawString(prefix)
@@ -0,0 +1,120 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// config immutable snapshot of the logger settings, replaced as a whole on every change
+type config struct {
+	level        uint32
+	writer       io.Writer
+	formatter    Formatter
+	errorHandler ErrorHandler
+	fallback     io.Writer
+	caller       bool
+	fields       []interface{}
+	hooks        []Hook
+	clock        func() time.Time
+	sinks        []Sink
+	sampler      *Sampler
+	namedLevels  map[string]uint32
+	inflight     *atomic.Int64
+}
+
+// Option changes logger settings, see New and Log.Reconfigure
+type Option func(c *config) error
+
+// Hook is called for every message before encoding, it can add, change or remove fields
+type Hook func(m *Message)
+
+// WithLevel set default level
+func WithLevel(v uint32) Option {
+	return func(c *config) error {
+		c.level = v
+		return nil
+	}
+}
+
+// WithOutput set writer
+func WithOutput(out io.Writer) Option {
+	return func(c *config) error {
+		if out == nil {
+			return fmt.Errorf("logx: nil output")
+		}
+		c.writer = out
+		return nil
+	}
+}
+
+// WithFormatter set formatter
+func WithFormatter(f Formatter) Option {
+	return func(c *config) error {
+		if f == nil {
+			return fmt.Errorf("logx: nil formatter")
+		}
+		c.formatter = f
+		return nil
+	}
+}
+
+// WithNamedLevels replace all level overrides of named loggers, see Log.SetNamedLevel
+func WithNamedLevels(levels map[string]uint32) Option {
+	return func(c *config) error {
+		c.namedLevels = make(map[string]uint32, len(levels))
+		for k, l := range levels {
+			c.namedLevels[k] = l
+		}
+		return nil
+	}
+}
+
+// WithCaller enable or disable source code location of the log call in messages
+func WithCaller(enable bool) Option {
+	return func(c *config) error {
+		c.caller = enable
+		return nil
+	}
+}
+
+// WithFields set key-value pairs added to every message
+func WithFields(args ...interface{}) Option {
+	return func(c *config) error {
+		if len(args)%2 != 0 {
+			return fmt.Errorf("logx: odd number of fields")
+		}
+		c.fields = append(make([]interface{}, 0, len(args)), args...)
+		return nil
+	}
+}
+
+// WithHooks set hooks called for every message before encoding, replaces previous hooks
+func WithHooks(hooks ...Hook) Option {
+	return func(c *config) error {
+		for _, h := range hooks {
+			if h == nil {
+				return fmt.Errorf("logx: nil hook")
+			}
+		}
+		c.hooks = append(make([]Hook, 0, len(hooks)), hooks...)
+		return nil
+	}
+}
+
+// WithClock set source of the message time, default time.Now
+func WithClock(clock func() time.Time) Option {
+	return func(c *config) error {
+		if clock == nil {
+			return fmt.Errorf("logx: nil clock")
+		}
+		c.clock = clock
+		return nil
+	}
+}
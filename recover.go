@@ -0,0 +1,114 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+)
+
+// RecoverOption changes behavior of Recover, Go and RecoverHandler
+type RecoverOption func(o *recoverOptions)
+
+type recoverOptions struct {
+	repanic bool
+}
+
+// Repanic panic again with the same value after the record is written
+func Repanic() RecoverOption {
+	return func(o *recoverOptions) {
+		o.repanic = true
+	}
+}
+
+func newRecoverOptions(opts []RecoverOption) recoverOptions {
+	o := recoverOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Recover must be called by defer directly, it writes an ERROR record with the panic value,
+// stack trace and goroutine id. Nil logger means the default logger.
+//
+//	defer logx.Recover(l)
+func Recover(l Logger, opts ...RecoverOption) {
+	if v := recover(); v != nil {
+		logPanic(l, v, newRecoverOptions(opts))
+	}
+}
+
+// Go run fn in a goroutine with Recover
+func Go(l Logger, fn func(), opts ...RecoverOption) {
+	o := newRecoverOptions(opts)
+	go func() {
+		defer func() {
+			if v := recover(); v != nil {
+				logPanic(l, v, o)
+			}
+		}()
+		fn()
+	}()
+}
+
+// RecoverHandler returns middleware which recovers panics of the handler and responds
+// with 500 if nothing was written yet. Nil logger means the logger of the request context,
+// see FromContext. http.ErrAbortHandler is passed through without a record.
+func RecoverHandler(l Logger, opts ...RecoverOption) func(next http.Handler) http.Handler {
+	o := newRecoverOptions(opts)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rw := &responseWriter{ResponseWriter: w}
+			defer func() {
+				v := recover()
+				if v == nil {
+					return
+				}
+				if err, ok := v.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+					panic(v)
+				}
+				if rw.status == 0 {
+					http.Error(rw, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				}
+				ll := l
+				if ll == nil {
+					ll = FromContext(r.Context())
+				}
+				logPanic(ll, v, o, "method", r.Method, "path", r.URL.Path)
+			}()
+			next.ServeHTTP(rw, r)
+		})
+	}
+}
+
+func logPanic(l Logger, v interface{}, o recoverOptions, args ...interface{}) {
+	if l == nil {
+		l = Default()
+	}
+	stack := debug.Stack()
+	args = append(args, "panic", v, "goroutine", goroutineID(stack), "stack", string(stack))
+	l.Error("panic recovered", args...)
+	if o.repanic {
+		panic(v)
+	}
+}
+
+// goroutineID from the first line of the stack: "goroutine 42 [running]:"
+func goroutineID(stack []byte) uint64 {
+	stack = bytes.TrimPrefix(stack, []byte("goroutine "))
+	if i := bytes.IndexByte(stack, ' '); i > 0 {
+		stack = stack[:i]
+	}
+	id, err := strconv.ParseUint(string(stack), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
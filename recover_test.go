@@ -0,0 +1,82 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/logx"
+	"go.osspkg.com/logx/logxtest"
+)
+
+func TestUnit_Recover(t *testing.T) {
+	l, o := logxtest.New()
+
+	func() {
+		defer logx.Recover(l)
+		panic("boom")
+	}()
+	casecheck.Equal(t, 1, o.Len())
+	e := o.All()[0]
+	casecheck.Equal(t, logx.Level(logx.LevelError), e.Level)
+	v, _ := e.Field("panic")
+	casecheck.Equal(t, "boom", v)
+	v, _ = e.Field("goroutine")
+	casecheck.True(t, v.(uint64) > 0)
+	v, _ = e.Field("stack")
+	casecheck.True(t, strings.Contains(v.(string), "recover_test.go"))
+
+	var repanic interface{}
+	func() {
+		defer func() { repanic = recover() }()
+		defer logx.Recover(l, logx.Repanic())
+		panic("again")
+	}()
+	casecheck.Equal(t, "again", repanic)
+	o.AssertLogged(t, logx.LevelError, "panic recovered", "panic", "again")
+
+	logx.Go(l, func() {
+		panic("in goroutine")
+	})
+	for i := 0; i < 100 && o.Len() < 3; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	o.AssertLogged(t, logx.LevelError, "panic recovered", "panic", "in goroutine")
+}
+
+func TestUnit_RecoverHandler(t *testing.T) {
+	l, o := logxtest.New()
+
+	h := logx.RecoverHandler(nil)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("handler")
+	}))
+	h = logx.AccessLog(l, logx.AccessLogOptions{})(h)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/p", nil))
+	casecheck.Equal(t, http.StatusInternalServerError, w.Code)
+
+	id := w.Header().Get(logx.HeaderRequestID)
+	o.AssertLogged(t, logx.LevelError, "panic recovered", "request_id", id, "path", "/p", "panic", "handler")
+	o.AssertLogged(t, logx.LevelError, "http request", "status", http.StatusInternalServerError)
+
+	abort := logx.RecoverHandler(l)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+	var v interface{}
+	func() {
+		defer func() { v = recover() }()
+		abort.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	}()
+	casecheck.Equal(t, http.ErrAbortHandler, v)
+	o.AssertCount(t, 2)
+}
@@ -0,0 +1,212 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	// drainTimeout limits waiting for in-flight writes before replaced outputs are closed
+	drainTimeout = 5 * time.Second
+	// reloadRetryDelay pause before reading the config file again when it changed during the read
+	reloadRetryDelay = 50 * time.Millisecond
+)
+
+// Apply validate config and apply it to the logger atomically. Messages written
+// concurrently use either the previous or the new configuration. Replaced outputs
+// are closed after in-flight writes to them are finished.
+// On error the logger keeps the previous configuration.
+func (c *Config) Apply(l *Log) error {
+	opts, closeAll, err := c.options()
+	if err != nil {
+		return err
+	}
+	prev, err := l.core.swap(opts...)
+	if err != nil {
+		closeAll()
+		return err
+	}
+	return l.core.release(prev, l.core.config.Load())
+}
+
+// release waits for in-flight writes of all superseded configs, not only prev,
+// because earlier configs replaced by SetLevel or SetOutput may share its outputs,
+// then closes outputs of prev not used by next
+func (c *logCore) release(prev, next *config) error {
+	c.drain(time.Now().Add(drainTimeout))
+
+	used := func(w interface{}) bool {
+		if w == next.writer {
+			return true
+		}
+		for _, s := range next.sinks {
+			if s == w {
+				return true
+			}
+		}
+		return false
+	}
+
+	var errs []error
+	if !used(prev.writer) {
+		errs = append(errs, closeWriter(prev.writer))
+	}
+	for _, s := range prev.sinks {
+		if !used(s) {
+			errs = append(errs, closeSinks([]Sink{s}))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("logx close replaced outputs: %w", err)
+	}
+	return nil
+}
+
+// Reloader applies config file to the logger on SIGHUP and when the file changes on disk.
+// Invalid configs are reported to the error handler of the logger and skipped.
+type Reloader struct {
+	// Decode config file content, default json.Unmarshal, e.g. yaml.Unmarshal for YAML files
+	Decode func(b []byte, v interface{}) error
+	// Interval of checking the file for changes, default 5s, negative disables polling
+	Interval time.Duration
+	// Env applies LOGX_* environment variables on top of the file, see Config.LoadEnv
+	Env bool
+
+	log  *Log
+	path string
+
+	mux  sync.Mutex
+	stat fileStamp
+}
+
+type fileStamp struct {
+	mod  time.Time
+	size int64
+}
+
+func (v fileStamp) equal(o fileStamp) bool {
+	return v.mod.Equal(o.mod) && v.size == o.size
+}
+
+// NewReloader create reloader of the config file for the logger
+func NewReloader(l *Log, path string) *Reloader {
+	return &Reloader{
+		Decode:   json.Unmarshal,
+		Interval: 5 * time.Second,
+		log:      l,
+		path:     path,
+	}
+}
+
+// Reload read config file and apply it to the logger
+func (v *Reloader) Reload() error {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	if err := v.reload(); err != nil {
+		err = fmt.Errorf("logx reload %s: %w", v.path, err)
+		v.log.core.config.Load().errorHandler(err)
+		return err
+	}
+	return nil
+}
+
+func (v *Reloader) reload() error {
+	b, err := v.read()
+	if err != nil {
+		return err
+	}
+	conf := &Config{}
+	if err = v.Decode(b, conf); err != nil {
+		return err
+	}
+	if v.Env {
+		if err = conf.LoadEnv(); err != nil {
+			return err
+		}
+	}
+	return conf.Apply(v.log)
+}
+
+// read file content, the read is retried once if the file changed meanwhile,
+// e.g. it was read while being written in place
+func (v *Reloader) read() ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		before, err := statFile(v.path)
+		if err != nil {
+			return nil, err
+		}
+		b, err := os.ReadFile(v.path)
+		if err != nil {
+			return nil, err
+		}
+		after, err := statFile(v.path)
+		if err != nil {
+			return nil, err
+		}
+		v.stat = after
+		if (after.equal(before) && after.size == int64(len(b))) || attempt > 0 {
+			return b, nil
+		}
+		time.Sleep(reloadRetryDelay)
+	}
+}
+
+func statFile(path string) (fileStamp, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}, err
+	}
+	return fileStamp{mod: info.ModTime(), size: info.Size()}, nil
+}
+
+func (v *Reloader) changed() bool {
+	stat, err := statFile(v.path)
+	if err != nil {
+		return false
+	}
+	v.mux.Lock()
+	defer v.mux.Unlock()
+	return !stat.equal(v.stat)
+}
+
+// Run applies config and watches for changes until ctx is done, suitable for syncing.Group.Background
+func (v *Reloader) Run(ctx context.Context) {
+	v.Reload() //nolint:errcheck
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	var tick <-chan time.Time
+	if v.Interval > 0 {
+		ticker := time.NewTicker(v.Interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+			v.Reload() //nolint:errcheck
+		case <-tick:
+			if v.changed() {
+				v.Reload() //nolint:errcheck
+			}
+		}
+	}
+}
@@ -0,0 +1,146 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/logx"
+)
+
+func TestUnit_Reloader(t *testing.T) {
+	dir := t.TempDir()
+	confPath := filepath.Join(dir, "logx.json")
+	logA, logB := filepath.Join(dir, "a.log"), filepath.Join(dir, "b.log")
+
+	replaceConf := func(data string) {
+		tmp := confPath + ".tmp"
+		casecheck.NoError(t, os.WriteFile(tmp, []byte(data), 0644))
+		casecheck.NoError(t, os.Rename(tmp, confPath))
+	}
+	writeConf := func(level, path string) {
+		replaceConf(`{"level":"` + level + `","format":"string","outputs":[{"type":"file","path":"` + path + `"}]}`)
+	}
+	readLog := func(path string) string {
+		b, err := os.ReadFile(path)
+		casecheck.NoError(t, err)
+		return string(b)
+	}
+
+	var (
+		mux  sync.Mutex
+		errs []error
+	)
+	l := logx.New(logx.WithErrorHandler(func(err error) {
+		mux.Lock()
+		errs = append(errs, err)
+		mux.Unlock()
+	}))
+
+	writeConf("info", logA)
+	r := logx.NewReloader(l, confPath)
+	r.Interval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		r.Run(ctx)
+		close(done)
+	}()
+
+	waitFor := func(call func() bool) {
+		for i := 0; i < 200 && !call(); i++ {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	waitFor(func() bool { return l.GetLevel() == logx.LevelInfo })
+	l.Info("to a")
+	l.Debug("hidden")
+
+	time.Sleep(20 * time.Millisecond)
+	writeConf("debug", logB)
+	waitFor(func() bool { return l.GetLevel() == logx.LevelDebug })
+	l.Debug("to b")
+
+	replaceConf(`{"level":"loud"}`)
+	waitFor(func() bool {
+		mux.Lock()
+		defer mux.Unlock()
+		return len(errs) > 0
+	})
+	l.Debug("still b")
+
+	cancel()
+	<-done
+	casecheck.NoError(t, l.Close())
+
+	a, b := readLog(logA), readLog(logB)
+	casecheck.Contains(t, a, "\"msg\"=\"to a\"")
+	casecheck.False(t, strings.Contains(a, "hidden"))
+	casecheck.Contains(t, b, "\"msg\"=\"to b\"")
+	casecheck.Contains(t, b, "\"msg\"=\"still b\"")
+
+	mux.Lock()
+	defer mux.Unlock()
+	casecheck.Equal(t, 1, len(errs))
+	casecheck.Contains(t, errs[0].Error(), "unknown level")
+}
+
+type blockingWriter struct {
+	mux     sync.Mutex
+	started chan struct{}
+	release chan struct{}
+	written bool
+	early   bool
+}
+
+func (v *blockingWriter) Write(b []byte) (int, error) {
+	close(v.started)
+	<-v.release
+	v.mux.Lock()
+	defer v.mux.Unlock()
+	v.written = true
+	return len(b), nil
+}
+
+func (v *blockingWriter) Close() error {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+	v.early = !v.written
+	return nil
+}
+
+func TestUnit_ConfigApply_DrainsSuperseded(t *testing.T) {
+	w := &blockingWriter{started: make(chan struct{}), release: make(chan struct{})}
+	l := logx.New()
+	l.SetOutput(w)
+
+	go l.Error("slow")
+	<-w.started
+	l.SetLevel(logx.LevelDebug)
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		close(w.release)
+	}()
+	conf := &logx.Config{Outputs: []logx.OutputConfig{{Type: "file", Path: filepath.Join(t.TempDir(), "a.log")}}}
+	casecheck.NoError(t, conf.Apply(l))
+	defer l.Close() //nolint:errcheck
+
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	casecheck.True(t, w.written)
+	casecheck.False(t, w.early)
+}
@@ -0,0 +1,80 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+const samplerBuckets = 4096
+
+// Sampler limits repeated messages: within every tick the first Initial messages
+// with the same level and text are written, then every Thereafter-th one.
+// Messages are grouped by hash, rare collisions share the counter.
+type Sampler struct {
+	initial    uint64
+	thereafter uint64
+	tick       int64
+	buckets    [samplerBuckets]samplerBucket
+}
+
+type samplerBucket struct {
+	reset atomic.Int64
+	count atomic.Uint64
+}
+
+// NewSampler create sampler, thereafter 0 drops all messages after the initial ones
+func NewSampler(tick time.Duration, initial, thereafter uint64) *Sampler {
+	if tick <= 0 {
+		tick = time.Second
+	}
+	return &Sampler{
+		initial:    initial,
+		thereafter: thereafter,
+		tick:       int64(tick),
+	}
+}
+
+// Allow reports whether the message should be written
+func (v *Sampler) Allow(level uint32, message string, now time.Time) bool {
+	b := &v.buckets[(hashString(message)^level)%samplerBuckets]
+
+	ts := now.UnixNano()
+	if reset := b.reset.Load(); ts > reset {
+		if b.reset.CompareAndSwap(reset, ts+v.tick) {
+			b.count.Store(0)
+		}
+	}
+
+	n := b.count.Add(1)
+	if n <= v.initial {
+		return true
+	}
+	if v.thereafter == 0 {
+		return false
+	}
+	return (n-v.initial)%v.thereafter == 0
+}
+
+// WithSampler set sampler of repeated messages, nil disables sampling.
+// Fatal and panic messages are never sampled.
+func WithSampler(s *Sampler) Option {
+	return func(c *config) error {
+		c.sampler = s
+		return nil
+	}
+}
+
+// hashString FNV-1a without allocations
+func hashString(s string) uint32 {
+	h := uint32(2166136261)
+	for i := 0; i < len(s); i++ {
+		h ^= uint32(s[i])
+		h *= 16777619
+	}
+	return h
+}
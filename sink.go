@@ -0,0 +1,77 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Sink receives every message written by the logger in addition to the main output.
+// The message is reused after the call, sinks must not keep references to it, see Message.Clone.
+type Sink interface {
+	WriteMessage(m *Message) error
+}
+
+type writerSink struct {
+	writer    io.Writer
+	formatter Formatter
+}
+
+// NewSink output with its own formatter
+func NewSink(w io.Writer, f Formatter) Sink {
+	return &writerSink{writer: w, formatter: f}
+}
+
+func (v *writerSink) WriteMessage(m *Message) error {
+	return v.formatter.Encode(v.writer, m)
+}
+
+func (v *writerSink) Sync() error {
+	return syncWriter(v.writer)
+}
+
+func (v *writerSink) Close() error {
+	return closeWriter(v.writer)
+}
+
+// WithSinks set additional outputs, replaces previous sinks
+func WithSinks(sinks ...Sink) Option {
+	return func(c *config) error {
+		for _, s := range sinks {
+			if s == nil {
+				return fmt.Errorf("logx: nil sink")
+			}
+		}
+		c.sinks = append(make([]Sink, 0, len(sinks)), sinks...)
+		return nil
+	}
+}
+
+func syncSinks(sinks []Sink) error {
+	var errs []error
+	for _, s := range sinks {
+		if v, ok := s.(Syncer); ok {
+			if err := v.Sync(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func closeSinks(sinks []Sink) error {
+	var errs []error
+	for _, s := range sinks {
+		if v, ok := s.(io.Closer); ok {
+			if err := v.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
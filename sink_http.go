@@ -0,0 +1,353 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// BatchEncoder writes a batch of messages as one request body
+type BatchEncoder interface {
+	ContentType() string
+	EncodeBatch(w io.Writer, batch []*Message) error
+}
+
+// HTTPSinkOptions settings of HTTPSink, zero values use the defaults
+type HTTPSinkOptions struct {
+	// URL of the push endpoint
+	URL string
+	// Encoder wire format of the batch, see LokiEncoder, ElasticEncoder and SplunkEncoder
+	Encoder BatchEncoder
+	// Header added to every request, e.g. Authorization
+	Header http.Header
+	// Client default with 10s timeout
+	Client *http.Client
+	// Gzip compress request bodies
+	Gzip bool
+
+	// BatchCount max messages in a batch, default 1000
+	BatchCount int
+	// BatchBytes approximate max size of a batch before encoding, default 1MB
+	BatchBytes int
+	// FlushInterval max time a message waits in a batch, default 1s
+	FlushInterval time.Duration
+	// BufferSize max messages waiting for a batch, default 10000
+	BufferSize int
+
+	// MaxRetries of a batch on network errors, 429 and 5xx, default 5
+	MaxRetries int
+	// MinBackoff and MaxBackoff of retries, default 100ms and 10s
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// ErrorHandler receives errors of batches which were not delivered, default DefaultErrorHandler
+	ErrorHandler ErrorHandler
+}
+
+// HTTPSinkStats counters of HTTPSink
+type HTTPSinkStats struct {
+	// Sent messages accepted by the endpoint
+	Sent uint64
+	// Dropped messages because of the full buffer or failed delivery
+	Dropped uint64
+	// Requests count including retries
+	Requests uint64
+}
+
+// HTTPSink ships messages in batches over HTTP in the background.
+// A batch is sent when it reaches BatchCount or BatchBytes, or after FlushInterval.
+type HTTPSink struct {
+	opts HTTPSinkOptions
+
+	queue   chan *Message
+	flush   chan chan error
+	quit    chan struct{}
+	stopped chan struct{}
+	// mux orders WriteMessage before Close, so no message is queued after the final drain
+	mux    sync.RWMutex
+	closed bool
+
+	sent     atomic.Uint64
+	dropped  atomic.Uint64
+	requests atomic.Uint64
+}
+
+// NewHTTPSink validate options and start shipping
+func NewHTTPSink(opts HTTPSinkOptions) (*HTTPSink, error) {
+	if len(opts.URL) == 0 {
+		return nil, fmt.Errorf("logx http sink: empty url")
+	}
+	if opts.Encoder == nil {
+		return nil, fmt.Errorf("logx http sink: nil encoder")
+	}
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if opts.BatchCount <= 0 {
+		opts.BatchCount = 1000
+	}
+	if opts.BatchBytes <= 0 {
+		opts.BatchBytes = 1 << 20
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = time.Second
+	}
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = 10000
+	}
+	if opts.MaxRetries < 0 {
+		opts.MaxRetries = 0
+	} else if opts.MaxRetries == 0 {
+		opts.MaxRetries = 5
+	}
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = 100 * time.Millisecond
+	}
+	if opts.MaxBackoff < opts.MinBackoff {
+		opts.MaxBackoff = max(10*time.Second, opts.MinBackoff)
+	}
+	if opts.ErrorHandler == nil {
+		opts.ErrorHandler = DefaultErrorHandler
+	}
+
+	v := &HTTPSink{
+		opts:    opts,
+		queue:   make(chan *Message, opts.BufferSize),
+		flush:   make(chan chan error),
+		quit:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go v.run()
+	return v, nil
+}
+
+// WriteMessage queue a copy of the message, it is dropped if the buffer is full
+func (v *HTTPSink) WriteMessage(m *Message) error {
+	v.mux.RLock()
+	defer v.mux.RUnlock()
+
+	if v.closed {
+		return fmt.Errorf("logx http sink: %w", io.ErrClosedPipe)
+	}
+	select {
+	case v.queue <- m.Clone():
+	default:
+		v.dropped.Add(1)
+	}
+	return nil
+}
+
+// Stats current counters
+func (v *HTTPSink) Stats() HTTPSinkStats {
+	return HTTPSinkStats{
+		Sent:     v.sent.Load(),
+		Dropped:  v.dropped.Load(),
+		Requests: v.requests.Load(),
+	}
+}
+
+// Flush send all queued messages and wait for the result
+func (v *HTTPSink) Flush() error {
+	result := make(chan error, 1)
+	select {
+	case v.flush <- result:
+		return <-result
+	case <-v.stopped:
+		return nil
+	}
+}
+
+// Sync same as Flush
+func (v *HTTPSink) Sync() error {
+	return v.Flush()
+}
+
+// Close send queued messages and stop shipping
+func (v *HTTPSink) Close() error {
+	v.mux.Lock()
+	if !v.closed {
+		v.closed = true
+		close(v.quit)
+	}
+	v.mux.Unlock()
+
+	<-v.stopped
+	return nil
+}
+
+func (v *HTTPSink) run() {
+	defer close(v.stopped)
+
+	ticker := time.NewTicker(v.opts.FlushInterval)
+	defer ticker.Stop()
+
+	var (
+		batch []*Message
+		size  int
+	)
+	add := func(m *Message) error {
+		batch = append(batch, m)
+		size += messageSize(m)
+		if len(batch) < v.opts.BatchCount && size < v.opts.BatchBytes {
+			return nil
+		}
+		err := v.ship(batch)
+		batch, size = nil, 0
+		return err
+	}
+	drain := func() (err error) {
+		for {
+			select {
+			case m := <-v.queue:
+				if e := add(m); e != nil {
+					err = e
+				}
+			default:
+				if len(batch) > 0 {
+					if e := v.ship(batch); e != nil {
+						err = e
+					}
+					batch, size = nil, 0
+				}
+				return err
+			}
+		}
+	}
+
+	for {
+		select {
+		case m := <-v.queue:
+			add(m) //nolint:errcheck
+		case <-ticker.C:
+			if len(batch) > 0 {
+				v.ship(batch) //nolint:errcheck
+				batch, size = nil, 0
+			}
+		case result := <-v.flush:
+			result <- drain()
+		case <-v.quit:
+			drain() //nolint:errcheck
+			return
+		}
+	}
+}
+
+// messageSize approximate size of the encoded message
+func messageSize(m *Message) int {
+	n := 64 + len(m.Level) + len(m.Message) + len(m.Caller.File)
+	for _, item := range m.Ctx {
+		if s, ok := item.(string); ok {
+			n += len(s) + 4
+		} else {
+			n += 16
+		}
+	}
+	return n
+}
+
+// ship encode and send the batch with retries, errors are passed to the error handler too
+func (v *HTTPSink) ship(batch []*Message) error {
+	body, err := v.encode(batch)
+	if err == nil {
+		err = v.send(body)
+	}
+	if err != nil {
+		v.dropped.Add(uint64(len(batch)))
+		err = fmt.Errorf("logx http sink: %d messages: %w", len(batch), err)
+		v.opts.ErrorHandler(err)
+		return err
+	}
+	v.sent.Add(uint64(len(batch)))
+	return nil
+}
+
+func (v *HTTPSink) encode(batch []*Message) ([]byte, error) {
+	var buf bytes.Buffer
+	if !v.opts.Gzip {
+		if err := v.opts.Encoder.EncodeBatch(&buf, batch); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	zw := gzip.NewWriter(&buf)
+	if err := v.opts.Encoder.EncodeBatch(zw, batch); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (v *HTTPSink) send(body []byte) error {
+	backoff := v.opts.MinBackoff
+	for attempt := 0; ; attempt++ {
+		retry, wait, err := v.request(body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= v.opts.MaxRetries {
+			return err
+		}
+		if wait <= 0 {
+			wait = backoff
+			backoff = min(backoff*2, v.opts.MaxBackoff)
+		}
+		t := time.NewTimer(min(wait, v.opts.MaxBackoff))
+		select {
+		case <-t.C:
+		case <-v.quit:
+			t.Stop()
+			return fmt.Errorf("closed while retrying: %w", err)
+		}
+	}
+}
+
+// request send the body once, returns whether the error is temporary and the delay asked by the server
+func (v *HTTPSink) request(body []byte) (bool, time.Duration, error) {
+	v.requests.Add(1)
+	req, err := http.NewRequest(http.MethodPost, v.opts.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, 0, err
+	}
+	for k, values := range v.opts.Header {
+		for _, value := range values {
+			req.Header.Add(k, value)
+		}
+	}
+	req.Header.Set("Content-Type", v.opts.Encoder.ContentType())
+	if v.opts.Gzip {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+
+	resp, err := v.opts.Client.Do(req)
+	if err != nil {
+		return true, 0, err
+	}
+	defer resp.Body.Close()                              //nolint:errcheck
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512)) //nolint:errcheck
+
+	switch {
+	case resp.StatusCode < 300:
+		return false, 0, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		var wait time.Duration
+		if sec, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && sec > 0 {
+			wait = time.Duration(sec) * time.Second
+		}
+		return true, wait, fmt.Errorf("status %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	default:
+		return false, 0, fmt.Errorf("status %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+}
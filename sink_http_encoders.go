@@ -0,0 +1,188 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LokiEncoder Loki push API (/loki/api/v1/push), messages are grouped to streams by labels
+type LokiEncoder struct {
+	// Labels added to every stream, e.g. job or env
+	Labels map[string]string
+	// LabelKeys ctx keys used as labels, the level label is always added
+	LabelKeys []string
+	// Formatter of the log lines, default JSON
+	Formatter Formatter
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+func (v *LokiEncoder) ContentType() string {
+	return "application/json"
+}
+
+func (v *LokiEncoder) EncodeBatch(w io.Writer, batch []*Message) error {
+	f := v.Formatter
+	if f == nil {
+		f = NewFormatJSON()
+	}
+
+	var (
+		streams []*lokiStream
+		index   = make(map[string]*lokiStream)
+		line    bytes.Buffer
+	)
+	for _, m := range batch {
+		labels := v.labels(m)
+		key := labelsKey(labels)
+		s, ok := index[key]
+		if !ok {
+			s = &lokiStream{Stream: labels}
+			index[key] = s
+			streams = append(streams, s)
+		}
+
+		line.Reset()
+		if err := f.Encode(&line, m); err != nil {
+			return err
+		}
+		s.Values = append(s.Values, [2]string{
+			strconv.FormatInt(m.Time.UnixNano(), 10),
+			string(bytes.TrimSuffix(line.Bytes(), newLine)),
+		})
+	}
+
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{"streams": streams}); err != nil {
+		return fmt.Errorf("logx loki encode: %w", err)
+	}
+	return nil
+}
+
+func (v *LokiEncoder) labels(m *Message) map[string]string {
+	labels := make(map[string]string, len(v.Labels)+len(v.LabelKeys)+1)
+	for k, val := range v.Labels {
+		labels[k] = val
+	}
+	labels["level"] = strings.ToLower(m.Level)
+	for _, key := range v.LabelKeys {
+		for i := 0; i+1 < len(m.Ctx); i += 2 {
+			if ctxString(m.Ctx[i]) == key {
+				labels[key] = ctxString(m.Ctx[i+1])
+				break
+			}
+		}
+	}
+	return labels
+}
+
+func labelsKey(labels map[string]string) string {
+	var b strings.Builder
+	for _, k := range sortedKeys(labels) {
+		b.WriteString(k + "=" + labels[k] + ",")
+	}
+	return b.String()
+}
+
+// ElasticEncoder Elasticsearch _bulk API, NDJSON of create actions and documents
+type ElasticEncoder struct {
+	// Index or data stream name, empty if it is set in the URL (/<index>/_bulk)
+	Index string
+}
+
+func (v *ElasticEncoder) ContentType() string {
+	return "application/x-ndjson"
+}
+
+func (v *ElasticEncoder) EncodeBatch(w io.Writer, batch []*Message) error {
+	action := map[string]map[string]string{"create": {}}
+	if len(v.Index) > 0 {
+		action["create"]["_index"] = v.Index
+	}
+
+	enc := json.NewEncoder(w)
+	for _, m := range batch {
+		doc := messageDocument(m, 4)
+		doc["@timestamp"] = m.Time.UTC().Format(time.RFC3339Nano)
+		doc["level"] = m.Level
+		doc["message"] = m.Message
+		if err := enc.Encode(action); err != nil {
+			return fmt.Errorf("logx elastic encode: %w", err)
+		}
+		if err := enc.Encode(doc); err != nil {
+			return fmt.Errorf("logx elastic encode: %w", err)
+		}
+	}
+	return nil
+}
+
+// SplunkEncoder Splunk HTTP Event Collector (/services/collector/event),
+// the token is passed by HTTPSinkOptions.Header: "Authorization: Splunk <token>"
+type SplunkEncoder struct {
+	Host       string
+	Source     string
+	SourceType string
+	Index      string
+}
+
+type splunkEvent struct {
+	Time       json.Number            `json:"time"`
+	Host       string                 `json:"host,omitempty"`
+	Source     string                 `json:"source,omitempty"`
+	SourceType string                 `json:"sourcetype,omitempty"`
+	Index      string                 `json:"index,omitempty"`
+	Event      map[string]interface{} `json:"event"`
+}
+
+func (v *SplunkEncoder) ContentType() string {
+	return "application/json"
+}
+
+func (v *SplunkEncoder) EncodeBatch(w io.Writer, batch []*Message) error {
+	enc := json.NewEncoder(w)
+	for _, m := range batch {
+		event := messageDocument(m, 3)
+		event["level"] = m.Level
+		event["message"] = m.Message
+		err := enc.Encode(splunkEvent{
+			Time:       json.Number(strconv.FormatFloat(float64(m.Time.UnixMicro())/1e6, 'f', 6, 64)),
+			Host:       v.Host,
+			Source:     v.Source,
+			SourceType: v.SourceType,
+			Index:      v.Index,
+			Event:      event,
+		})
+		if err != nil {
+			return fmt.Errorf("logx splunk encode: %w", err)
+		}
+	}
+	return nil
+}
+
+// messageDocument ctx fields and caller as a JSON object, extra is space for the base fields
+func messageDocument(m *Message, extra int) map[string]interface{} {
+	doc := make(map[string]interface{}, len(m.Ctx)/2+extra)
+	if !m.Caller.IsZero() {
+		doc["caller"] = m.Caller.String()
+	}
+	for i := 0; i < len(m.Ctx); i += 2 {
+		var value interface{}
+		if i+1 < len(m.Ctx) {
+			value = m.Ctx[i+1]
+		}
+		doc[ctxString(m.Ctx[i])] = ctxString(value)
+	}
+	return doc
+}
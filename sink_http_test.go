@@ -0,0 +1,224 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx_test
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/logx"
+)
+
+type pushServer struct {
+	mux      sync.Mutex
+	bodies   [][]byte
+	headers  []http.Header
+	paths    []string
+	failures int
+}
+
+func (v *pushServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body io.Reader = r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		body = zr
+	}
+	b, err := io.ReadAll(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	v.mux.Lock()
+	defer v.mux.Unlock()
+	if v.failures > 0 {
+		v.failures--
+		w.Header().Set("Retry-After", "0")
+		http.Error(w, "slow down", http.StatusTooManyRequests)
+		return
+	}
+	v.bodies = append(v.bodies, b)
+	v.headers = append(v.headers, r.Header.Clone())
+	v.paths = append(v.paths, r.URL.Path)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (v *pushServer) requests() ([][]byte, []http.Header) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+	return v.bodies, v.headers
+}
+
+func TestUnit_HTTPSinkLoki(t *testing.T) {
+	srv := &pushServer{failures: 2}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	s, err := logx.NewHTTPSink(logx.HTTPSinkOptions{
+		URL:        ts.URL + "/loki/api/v1/push",
+		Encoder:    &logx.LokiEncoder{Labels: map[string]string{"job": "api"}, LabelKeys: []string{"tenant"}},
+		Header:     http.Header{"X-Scope-Orgid": []string{"team"}},
+		Gzip:       true,
+		MinBackoff: time.Millisecond,
+	})
+	casecheck.NoError(t, err)
+
+	l := logx.New(logx.WithOutput(io.Discard), logx.WithLevel(logx.LevelInfo), logx.WithSinks(s))
+	l.Info("a", "tenant", "t1")
+	l.Info("b", "tenant", "t2")
+	l.Error("c", "tenant", "t1")
+	l.Info("d", "tenant", "t1")
+	casecheck.NoError(t, l.Sync())
+
+	bodies, headers := srv.requests()
+	casecheck.Equal(t, 1, len(bodies))
+	casecheck.Equal(t, "team", headers[0].Get("X-Scope-Orgid"))
+	casecheck.Equal(t, "application/json", headers[0].Get("Content-Type"))
+
+	var push struct {
+		Streams []struct {
+			Stream map[string]string `json:"stream"`
+			Values [][2]string       `json:"values"`
+		} `json:"streams"`
+	}
+	casecheck.NoError(t, json.Unmarshal(bodies[0], &push))
+	casecheck.Equal(t, 3, len(push.Streams))
+	casecheck.Equal(t, map[string]string{"job": "api", "level": "info", "tenant": "t1"}, push.Streams[0].Stream)
+	casecheck.Equal(t, 2, len(push.Streams[0].Values))
+	casecheck.Contains(t, push.Streams[0].Values[0][1], "\"msg\":\"a\"")
+	casecheck.Contains(t, push.Streams[0].Values[1][1], "\"msg\":\"d\"")
+	casecheck.Equal(t, "error", push.Streams[2].Stream["level"])
+
+	stats := s.Stats()
+	casecheck.Equal(t, uint64(4), stats.Sent)
+	casecheck.Equal(t, uint64(3), stats.Requests)
+	casecheck.NoError(t, l.Close())
+}
+
+func TestUnit_HTTPSinkElasticBatches(t *testing.T) {
+	srv := &pushServer{}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	s, err := logx.NewHTTPSink(logx.HTTPSinkOptions{
+		URL:           ts.URL + "/_bulk",
+		Encoder:       &logx.ElasticEncoder{Index: "logs-api"},
+		BatchCount:    2,
+		FlushInterval: time.Hour,
+	})
+	casecheck.NoError(t, err)
+
+	l := logx.New(logx.WithOutput(io.Discard), logx.WithLevel(logx.LevelInfo), logx.WithSinks(s))
+	for _, msg := range []string{"m1", "m2", "m3"} {
+		l.Info(msg, "user", "u1", "message", "ctx key is overridden")
+	}
+	casecheck.NoError(t, l.Close())
+
+	bodies, headers := srv.requests()
+	casecheck.Equal(t, 2, len(bodies))
+	casecheck.Equal(t, "application/x-ndjson", headers[0].Get("Content-Type"))
+
+	lines := strings.Split(strings.TrimSpace(string(bodies[0])), "\n")
+	casecheck.Equal(t, 4, len(lines))
+	casecheck.Equal(t, `{"create":{"_index":"logs-api"}}`, lines[0])
+	var doc map[string]string
+	casecheck.NoError(t, json.Unmarshal([]byte(lines[1]), &doc))
+	casecheck.Equal(t, "m1", doc["message"])
+	casecheck.Equal(t, "INFO", doc["level"])
+	casecheck.Equal(t, "u1", doc["user"])
+	casecheck.NotEqual(t, "", doc["@timestamp"])
+	casecheck.Equal(t, 2, strings.Count(string(bodies[1]), "\n"))
+}
+
+func TestUnit_HTTPSinkSplunk(t *testing.T) {
+	srv := &pushServer{}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	var errs []error
+	s, err := logx.NewHTTPSink(logx.HTTPSinkOptions{
+		URL:          ts.URL + "/services/collector/event",
+		Encoder:      &logx.SplunkEncoder{Host: "h1", SourceType: "_json"},
+		Header:       http.Header{"Authorization": []string{"Splunk token"}},
+		ErrorHandler: func(err error) { errs = append(errs, err) },
+		MinBackoff:   time.Millisecond,
+	})
+	casecheck.NoError(t, err)
+
+	m := &logx.Message{
+		Time:    time.Unix(1700000000, 500000000),
+		Level:   "WARN",
+		Message: "hello",
+		Ctx:     []interface{}{"k", 1},
+	}
+	casecheck.NoError(t, s.WriteMessage(m))
+	m.Message = "reused by the logger"
+	casecheck.NoError(t, s.Flush())
+
+	bodies, headers := srv.requests()
+	casecheck.Equal(t, "Splunk token", headers[0].Get("Authorization"))
+	sc := bufio.NewScanner(bytes.NewReader(bodies[0]))
+	casecheck.True(t, sc.Scan())
+	casecheck.Equal(t, `{"time":1700000000.500000,"host":"h1","sourcetype":"_json","event":{"k":"1","level":"WARN","message":"hello"}}`, sc.Text())
+	casecheck.False(t, sc.Scan())
+
+	srv.mux.Lock()
+	srv.failures = 100
+	srv.mux.Unlock()
+	casecheck.NoError(t, s.WriteMessage(m))
+	casecheck.Error(t, s.Flush())
+	casecheck.Equal(t, 1, len(errs))
+	casecheck.Contains(t, errs[0].Error(), "status 429")
+	casecheck.Equal(t, uint64(1), s.Stats().Dropped)
+	casecheck.NoError(t, s.Close())
+}
+
+func TestUnit_HTTPSinkCloseRace(t *testing.T) {
+	srv := &pushServer{}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	s, err := logx.NewHTTPSink(logx.HTTPSinkOptions{URL: ts.URL, Encoder: &logx.ElasticEncoder{Index: "logs"}})
+	casecheck.NoError(t, err)
+
+	var (
+		wg       sync.WaitGroup
+		accepted atomic.Uint64
+	)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 500; j++ {
+				if s.WriteMessage(&logx.Message{Level: "INFO", Message: "m"}) == nil {
+					accepted.Add(1)
+				}
+			}
+		}()
+	}
+	time.Sleep(time.Millisecond)
+	casecheck.NoError(t, s.Close())
+	wg.Wait()
+
+	stats := s.Stats()
+	casecheck.Equal(t, accepted.Load(), stats.Sent+stats.Dropped)
+}
@@ -0,0 +1,87 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"log"
+	"strings"
+)
+
+// StdLogOption changes writer used for standard library log, see RedirectStdLog
+type StdLogOption func(w *stdWriter)
+
+// DetectLevelPrefix enable level detection by message prefix: "[WARN] text", "WARN: text", "[debug] text".
+// The prefix is removed from the message, messages without prefix use the default level.
+func DetectLevelPrefix() StdLogOption {
+	return func(w *stdWriter) {
+		w.detect = true
+	}
+}
+
+type stdWriter struct {
+	log    Logger
+	level  uint32
+	detect bool
+}
+
+func newStdWriter(l Logger, level uint32, opts []StdLogOption) *stdWriter {
+	w := &stdWriter{log: l, level: level}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+func (v *stdWriter) Write(b []byte) (int, error) {
+	msg, level := strings.TrimRight(string(b), "\r\n"), v.level
+	if v.detect {
+		msg, level = detectLevel(msg, level)
+	}
+	v.log.Log(level, msg)
+	return len(b), nil
+}
+
+func detectLevel(msg string, level uint32) (string, uint32) {
+	var name, rest string
+	switch {
+	case strings.HasPrefix(msg, "["):
+		i := strings.IndexByte(msg, ']')
+		if i < 0 {
+			return msg, level
+		}
+		name, rest = msg[1:i], msg[i+1:]
+	default:
+		i := strings.IndexByte(msg, ':')
+		if i < 0 || strings.ContainsAny(msg[:i], " \t") {
+			return msg, level
+		}
+		name, rest = msg[:i], msg[i+1:]
+	}
+	l, err := ParseLevel(name)
+	if err != nil || len(name) == 0 || (name[0] >= '0' && name[0] <= '9') {
+		return msg, level
+	}
+	return strings.TrimLeft(rest, " \t"), l.Uint32()
+}
+
+// NewStdLogger standard library logger writing to l with level, e.g. for http.Server.ErrorLog
+func NewStdLogger(l Logger, level uint32, opts ...StdLogOption) *log.Logger {
+	return log.New(newStdWriter(l, level, opts), "", 0)
+}
+
+// RedirectStdLog send output of the standard library log package to l with level.
+// The returned function restores the previous output, flags and prefix.
+func RedirectStdLog(l Logger, level uint32, opts ...StdLogOption) (undo func()) {
+	out, flags, prefix := log.Writer(), log.Flags(), log.Prefix()
+	log.SetOutput(newStdWriter(l, level, opts))
+	log.SetFlags(0)
+	log.SetPrefix("")
+	return func() {
+		log.SetOutput(out)
+		log.SetFlags(flags)
+		log.SetPrefix(prefix)
+	}
+}
@@ -0,0 +1,43 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx_test
+
+import (
+	"log"
+	"strings"
+	"testing"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/logx"
+	"go.osspkg.com/logx/logxtest"
+)
+
+func TestUnit_RedirectStdLog(t *testing.T) {
+	l, o := logxtest.New(logx.WithCaller(true))
+
+	flags := log.Flags()
+	undo := logx.RedirectStdLog(l, logx.LevelInfo, logx.DetectLevelPrefix())
+	log.Printf("plain %d", 1)
+	log.Println("[WARN] disk is almost full")
+	log.Print("error: connection refused")
+	log.Print("[loud] unknown prefix")
+	log.Print("10: numeric prefix")
+	undo()
+	casecheck.Equal(t, flags, log.Flags())
+
+	o.AssertCount(t, 5)
+	o.AssertLogged(t, logx.LevelInfo, "plain 1")
+	o.AssertLogged(t, logx.LevelWarn, "disk is almost full")
+	o.AssertLogged(t, logx.LevelError, "connection refused")
+	o.AssertLogged(t, logx.LevelInfo, "[loud] unknown prefix")
+	o.AssertLogged(t, logx.LevelInfo, "10: numeric prefix")
+	casecheck.True(t, strings.HasSuffix(o.All()[0].Caller.File, "stdlog_test.go"))
+
+	std := logx.NewStdLogger(l, logx.LevelError)
+	std.Printf("[WARN] http: TLS handshake error")
+	o.AssertLogged(t, logx.LevelError, "[WARN] http: TLS handshake error")
+}
@@ -0,0 +1,352 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.osspkg.com/ioutils/data"
+)
+
+const (
+	SyslogRFC5424 = "rfc5424"
+	SyslogRFC3164 = "rfc3164"
+)
+
+const (
+	SyslogNetworkUnix     = "unix"
+	SyslogNetworkUnixgram = "unixgram"
+	SyslogNetworkUDP      = "udp"
+	SyslogNetworkTCP      = "tcp"
+	SyslogNetworkTLS      = "tls"
+)
+
+// syslogSDID structured data id of the context fields, 32473 is the example enterprise number of RFC 5612
+const syslogSDID = "logx@32473"
+
+var syslogLocalPaths = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+var syslogFacilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5, "lpr": 6, "news": 7,
+	"uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19,
+	"local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+// SyslogOptions settings of the syslog output, zero values use the defaults
+type SyslogOptions struct {
+	// Network unix, unixgram, udp, tcp or tls, empty for the local syslog (/dev/log)
+	Network string
+	// Address host:port or socket path
+	Address string
+	// TLSConfig of the tls network
+	TLSConfig *tls.Config
+	// DialTimeout default 5s
+	DialTimeout time.Duration
+	// Net options of the delivery, e.g. BufferSize and WriteTimeout,
+	// Network, Address, TLSConfig and Framing are set by SyslogOptions
+	Net NetOptions
+
+	// Format rfc5424 (default) or rfc3164
+	Format string
+	// Facility name (user, daemon, local0...) or number, default user
+	Facility string
+	// AppName default name of the executable
+	AppName string
+	// Hostname default os.Hostname
+	Hostname string
+}
+
+// NewSyslogSink output to syslog
+func NewSyslogSink(opts SyslogOptions) (Sink, error) {
+	return newSyslogSink(opts)
+}
+
+func newSyslogSink(opts SyslogOptions) (*writerSink, error) {
+	f, err := NewFormatSyslog(opts)
+	if err != nil {
+		return nil, err
+	}
+	w, err := NewSyslogWriter(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &writerSink{writer: w, formatter: f}, nil
+}
+
+// ParseSyslogFacility name or number of the facility
+func ParseSyslogFacility(s string) (int, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if len(s) == 0 {
+		return syslogFacilities["user"], nil
+	}
+	if v, ok := syslogFacilities[s]; ok {
+		return v, nil
+	}
+	if v, err := strconv.Atoi(s); err == nil && v >= 0 && v <= 23 {
+		return v, nil
+	}
+	return 0, fmt.Errorf("logx syslog: unknown facility %q", s)
+}
+
+// SyslogSeverity of the level: FATAL - crit, ERROR - err, WARN - warning, INFO - info, DEBUG - debug.
+// Custom levels use their Severity.
+func SyslogSeverity(level uint32) int {
+	switch uint32(Level(level).Severity()) {
+	case LevelFatal:
+		return 2
+	case LevelError:
+		return 3
+	case LevelWarn:
+		return 4
+	case LevelInfo:
+		return 6
+	default:
+		return 7
+	}
+}
+
+// FormatSyslog encodes a message as one syslog record, ctx fields are written
+// as structured data (RFC 5424) or key="value" pairs after the message (RFC 3164)
+type FormatSyslog struct {
+	rfc      string
+	facility int
+	hostname string
+	appName  string
+	pid      string
+}
+
+func NewFormatSyslog(opts SyslogOptions) (*FormatSyslog, error) {
+	rfc := strings.ToLower(opts.Format)
+	switch rfc {
+	case "":
+		rfc = SyslogRFC5424
+	case SyslogRFC5424, SyslogRFC3164:
+	default:
+		return nil, fmt.Errorf("logx syslog: unknown format %q", opts.Format)
+	}
+	facility, err := ParseSyslogFacility(opts.Facility)
+	if err != nil {
+		return nil, err
+	}
+	v := &FormatSyslog{
+		rfc:      rfc,
+		facility: facility,
+		hostname: opts.Hostname,
+		appName:  opts.AppName,
+		pid:      strconv.Itoa(os.Getpid()),
+	}
+	if len(v.hostname) == 0 {
+		v.hostname, _ = os.Hostname() //nolint:errcheck
+	}
+	if len(v.appName) == 0 {
+		v.appName = filepath.Base(os.Args[0])
+	}
+	v.hostname, v.appName = syslogHeader(v.hostname, 255), syslogHeader(v.appName, 48)
+	return v, nil
+}
+
+func (v *FormatSyslog) Encode(out io.Writer, m *Message) error {
+	w := poolBuffer.Get()
+	defer func() {
+		poolBuffer.Put(w)
+	}()
+
+	level, _ := ParseLevel(m.Level)                                                      //nolint:errcheck
+	w.WriteString("<" + strconv.Itoa(v.facility*8+SyslogSeverity(level.Uint32())) + ">") //nolint:errcheck
+
+	if v.rfc == SyslogRFC3164 {
+		v.encode3164(w, m)
+	} else {
+		v.encode5424(w, m)
+	}
+
+	if _, err := out.Write(w.Bytes()); err != nil {
+		return fmt.Errorf("logx syslog write: %w", err)
+	}
+	return nil
+}
+
+func (v *FormatSyslog) encode5424(w *data.Buffer, m *Message) {
+	w.WriteString("1 ")                                                    //nolint:errcheck
+	w.WriteString(m.Time.Format("2006-01-02T15:04:05.000000Z07:00") + " ") //nolint:errcheck
+	w.WriteString(v.hostname + " " + v.appName + " " + v.pid + " - ")      //nolint:errcheck
+
+	count := len(m.Ctx)
+	if count == 0 && m.Caller.IsZero() {
+		w.WriteByte('-') //nolint:errcheck
+	} else {
+		w.WriteString("[" + syslogSDID) //nolint:errcheck
+		if !m.Caller.IsZero() {
+			v.writeParam(w, "caller", m.Caller.String())
+		}
+		for i := 0; i < count; i += 2 {
+			var value interface{}
+			if i+1 < count {
+				value = m.Ctx[i+1]
+			}
+			v.writeParam(w, ctxString(m.Ctx[i]), ctxString(value))
+		}
+		w.WriteByte(']') //nolint:errcheck
+	}
+	if len(m.Message) > 0 {
+		w.WriteByte(' ')         //nolint:errcheck
+		w.WriteString(m.Message) //nolint:errcheck
+	}
+}
+
+func (v *FormatSyslog) writeParam(w *data.Buffer, key, value string) {
+	w.WriteByte(' ')                 //nolint:errcheck
+	w.WriteString(syslogSDName(key)) //nolint:errcheck
+	w.WriteString("=\"")             //nolint:errcheck
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '"', '\\', ']':
+			w.WriteByte('\\') //nolint:errcheck
+		}
+		w.WriteByte(value[i]) //nolint:errcheck
+	}
+	w.WriteByte('"') //nolint:errcheck
+}
+
+func (v *FormatSyslog) encode3164(w *data.Buffer, m *Message) {
+	w.WriteString(m.Time.Format(time.Stamp) + " ")                    //nolint:errcheck
+	w.WriteString(v.hostname + " " + v.appName + "[" + v.pid + "]: ") //nolint:errcheck
+	w.WriteString(m.Message)                                          //nolint:errcheck
+	if !m.Caller.IsZero() {
+		w.WriteString(" caller=\"" + m.Caller.String() + "\"") //nolint:errcheck
+	}
+	for i := 0; i < len(m.Ctx); i += 2 {
+		var value interface{}
+		if i+1 < len(m.Ctx) {
+			value = m.Ctx[i+1]
+		}
+		w.WriteString(" " + ctxString(m.Ctx[i]) + "=" + strconv.Quote(ctxString(value))) //nolint:errcheck
+	}
+}
+
+// syslogHeader printable ASCII without spaces, "-" for empty values
+func syslogHeader(s string, limit int) string {
+	s = strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' {
+			return '_'
+		}
+		return r
+	}, s)
+	if len(s) == 0 {
+		return "-"
+	}
+	return s[:min(len(s), limit)]
+}
+
+// syslogSDName printable ASCII without '=', ' ', ']' and '"', up to 32 chars
+func syslogSDName(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' || r == '=' || r == ']' || r == '"' {
+			return '_'
+		}
+		return r
+	}, s)
+	if len(s) == 0 {
+		return "_"
+	}
+	return s[:min(len(s), 32)]
+}
+
+// SyslogWriter sends every Write as one syslog record in the background based on NetWriter.
+// TCP and TLS use octet-counting framing (RFC 6587), unix stream sockets use newline-terminated
+// records as local syslog daemons expect. Write never blocks on the network: records are buffered
+// while the connection is re-established with backoff and dropped when the buffer is full, see Stats.
+type SyslogWriter struct {
+	net *NetWriter
+}
+
+// NewSyslogWriter start delivery to syslog. The local syslog socket must exist,
+// remote collectors may be unavailable yet.
+func NewSyslogWriter(opts SyslogOptions) (*SyslogWriter, error) {
+	nopts := opts.Net
+	nopts.Network, nopts.Address, nopts.TLSConfig = opts.Network, opts.Address, opts.TLSConfig
+	if nopts.DialTimeout <= 0 {
+		nopts.DialTimeout = opts.DialTimeout
+	}
+	if nopts.DialTimeout <= 0 {
+		nopts.DialTimeout = 5 * time.Second
+	}
+
+	switch opts.Network {
+	case "":
+		// the socket type of the local syslog is known only after dialing
+		conn, err := dialLocalSyslog(opts.Address, nopts.DialTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("logx syslog: %w", err)
+		}
+		nopts.Network, nopts.Address = conn.RemoteAddr().Network(), conn.RemoteAddr().String()
+		conn.Close() //nolint:errcheck
+	case SyslogNetworkUnix, SyslogNetworkUnixgram, SyslogNetworkUDP, SyslogNetworkTCP, SyslogNetworkTLS:
+	default:
+		return nil, fmt.Errorf("logx syslog: unknown network %q", opts.Network)
+	}
+
+	switch nopts.Network {
+	case SyslogNetworkTCP, SyslogNetworkTLS:
+		nopts.Framing = NetFramingOctet
+	case SyslogNetworkUnix:
+		nopts.Framing = NetFramingNewline
+	default:
+		nopts.Framing = NetFramingNone
+	}
+	w, err := NewNetWriter(nopts)
+	if err != nil {
+		return nil, fmt.Errorf("logx syslog: %w", err)
+	}
+	return &SyslogWriter{net: w}, nil
+}
+
+func dialLocalSyslog(address string, timeout time.Duration) (net.Conn, error) {
+	paths := syslogLocalPaths
+	if len(address) > 0 {
+		paths = []string{address}
+	}
+	var errs []error
+	for _, path := range paths {
+		for _, network := range []string{SyslogNetworkUnixgram, SyslogNetworkUnix} {
+			conn, err := net.DialTimeout(network, path, timeout)
+			if err == nil {
+				return conn, nil
+			}
+			errs = append(errs, err)
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
+func (v *SyslogWriter) Write(b []byte) (int, error) {
+	return v.net.Write(b)
+}
+
+// Stats counters of the delivery, including dropped records
+func (v *SyslogWriter) Stats() NetStats {
+	return v.net.Stats()
+}
+
+// Flush wait until buffered records are sent, see NetWriter.Flush
+func (v *SyslogWriter) Flush() error {
+	return v.net.Flush()
+}
+
+// Close stop delivery, see NetWriter.Close
+func (v *SyslogWriter) Close() error {
+	return v.net.Close()
+}
@@ -0,0 +1,260 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx_test
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/logx"
+)
+
+func TestUnit_FormatSyslog(t *testing.T) {
+	ts := time.Date(2026, 3, 4, 5, 6, 7, 8000, time.UTC)
+	pid := strconv.Itoa(os.Getpid())
+	m := &logx.Message{
+		Time:    ts,
+		Level:   "WARN",
+		Message: "disk is full",
+		Ctx:     []interface{}{"path", "/var", "quote", `a"b]c`, "bad key", 1},
+	}
+
+	f, err := logx.NewFormatSyslog(logx.SyslogOptions{Facility: "local0", AppName: "api", Hostname: "host1"})
+	casecheck.NoError(t, err)
+	var w bytes.Buffer
+	casecheck.NoError(t, f.Encode(&w, m))
+	casecheck.Equal(t, `<132>1 2026-03-04T05:06:07.000008Z host1 api `+pid+
+		` - [logx@32473 path="/var" quote="a\"b\]c" bad_key="1"] disk is full`, w.String())
+
+	f, err = logx.NewFormatSyslog(logx.SyslogOptions{Format: logx.SyslogRFC3164, AppName: "api", Hostname: "host1"})
+	casecheck.NoError(t, err)
+	w.Reset()
+	m.Level, m.Ctx = "ERROR", m.Ctx[:2]
+	casecheck.NoError(t, f.Encode(&w, m))
+	casecheck.Equal(t, `<11>Mar  4 05:06:07 host1 api[`+pid+`]: disk is full path="/var"`, w.String())
+
+	_, err = logx.NewFormatSyslog(logx.SyslogOptions{Facility: "nope"})
+	casecheck.Error(t, err)
+	casecheck.Equal(t, 2, logx.SyslogSeverity(logx.LevelPanic))
+	casecheck.Equal(t, 7, logx.SyslogSeverity(logx.LevelTrace))
+}
+
+func TestUnit_SyslogUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	casecheck.NoError(t, err)
+	defer conn.Close() //nolint:errcheck
+
+	s, err := logx.NewSyslogSink(logx.SyslogOptions{Network: logx.SyslogNetworkUDP, Address: conn.LocalAddr().String()})
+	casecheck.NoError(t, err)
+	l := logx.New(logx.WithOutput(&bytes.Buffer{}), logx.WithLevel(logx.LevelInfo), logx.WithSinks(s))
+	l.Info("over udp", "k", "v")
+	casecheck.NoError(t, l.Close())
+
+	buf := make([]byte, 1024)
+	casecheck.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	n, _, err := conn.ReadFrom(buf)
+	casecheck.NoError(t, err)
+	casecheck.True(t, strings.HasPrefix(string(buf[:n]), "<14>1 "))
+	casecheck.True(t, strings.HasSuffix(string(buf[:n]), `[logx@32473 k="v"] over udp`))
+}
+
+func TestUnit_SyslogUnixgram(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.sock")
+	conn, err := net.ListenPacket("unixgram", path)
+	casecheck.NoError(t, err)
+	defer conn.Close() //nolint:errcheck
+
+	w, err := logx.NewSyslogWriter(logx.SyslogOptions{Address: path})
+	casecheck.NoError(t, err)
+	_, err = w.Write([]byte("<14>record"))
+	casecheck.NoError(t, err)
+	casecheck.NoError(t, w.Close())
+
+	buf := make([]byte, 1024)
+	n, _, err := conn.ReadFrom(buf)
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, "<14>record", string(buf[:n]))
+
+	_, err = w.Write([]byte("after close"))
+	casecheck.Error(t, err)
+}
+
+func TestUnit_SyslogUnixStream(t *testing.T) {
+	ln, err := net.Listen("unix", filepath.Join(t.TempDir(), "log.sock"))
+	casecheck.NoError(t, err)
+	defer ln.Close() //nolint:errcheck
+
+	w, err := logx.NewSyslogWriter(logx.SyslogOptions{Address: ln.Addr().String()})
+	casecheck.NoError(t, err)
+	defer w.Close() //nolint:errcheck
+
+	conn, err := ln.Accept()
+	casecheck.NoError(t, err)
+	defer conn.Close() //nolint:errcheck
+
+	_, err = w.Write([]byte("<14>first"))
+	casecheck.NoError(t, err)
+	_, err = w.Write([]byte("<14>second\n"))
+	casecheck.NoError(t, err)
+
+	r := bufio.NewReader(conn)
+	for _, want := range []string{"<14>first\n", "<14>second\n"} {
+		line, err := r.ReadString('\n')
+		casecheck.NoError(t, err)
+		casecheck.Equal(t, want, line)
+	}
+}
+
+func TestUnit_SyslogTCPReconnect(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	casecheck.NoError(t, err)
+	defer ln.Close() //nolint:errcheck
+
+	w, err := logx.NewSyslogWriter(logx.SyslogOptions{Network: logx.SyslogNetworkTCP, Address: ln.Addr().String()})
+	casecheck.NoError(t, err)
+	defer w.Close() //nolint:errcheck
+
+	conn, err := ln.Accept()
+	casecheck.NoError(t, err)
+	_, err = w.Write([]byte("<14>first"))
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, "9 <14>first", readFrame(t, bufio.NewReader(conn)))
+	casecheck.NoError(t, conn.Close())
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		c, err := ln.Accept()
+		if err == nil {
+			accepted <- c
+		}
+	}()
+	// writes to the closed connection fail after the peer reset, then a new one is dialed
+	var next net.Conn
+	for i := 0; i < 100 && next == nil; i++ {
+		w.Write([]byte("<14>second")) //nolint:errcheck
+		select {
+		case next = <-accepted:
+		case <-time.After(20 * time.Millisecond):
+		}
+	}
+	casecheck.NotNil(t, next)
+	defer next.Close() //nolint:errcheck
+	casecheck.Equal(t, "10 <14>second", readFrame(t, bufio.NewReader(next)))
+}
+
+func TestUnit_SyslogTLS(t *testing.T) {
+	cert, pool := selfSignedCert(t)
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12})
+	casecheck.NoError(t, err)
+	defer ln.Close() //nolint:errcheck
+
+	result := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()                               //nolint:errcheck
+		line, _ := bufio.NewReader(conn).ReadString('d') //nolint:errcheck
+		result <- line
+	}()
+
+	w, err := logx.NewSyslogWriter(logx.SyslogOptions{
+		Network:   logx.SyslogNetworkTLS,
+		Address:   ln.Addr().String(),
+		TLSConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+	})
+	casecheck.NoError(t, err)
+	defer w.Close() //nolint:errcheck
+	_, err = w.Write([]byte("<14>secured"))
+	casecheck.NoError(t, err)
+
+	select {
+	case line := <-result:
+		casecheck.Equal(t, "11 <14>secured", line)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout")
+	}
+}
+
+func TestUnit_ConfigSyslog(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	casecheck.NoError(t, err)
+	defer conn.Close() //nolint:errcheck
+
+	conf := logx.Config{Level: "info", Outputs: []logx.OutputConfig{{
+		Type:     logx.OutputSyslog,
+		Network:  logx.SyslogNetworkUDP,
+		Address:  conn.LocalAddr().String(),
+		Format:   logx.SyslogRFC3164,
+		Facility: "daemon",
+		Tag:      "svc",
+	}}}
+	l, err := conf.Build()
+	casecheck.NoError(t, err)
+	l.Warn("from config")
+
+	buf := make([]byte, 1024)
+	casecheck.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	n, _, err := conn.ReadFrom(buf)
+	casecheck.NoError(t, err)
+	casecheck.True(t, strings.HasPrefix(string(buf[:n]), "<28>"))
+	casecheck.Contains(t, string(buf[:n]), " svc[")
+	casecheck.True(t, strings.HasSuffix(string(buf[:n]), "]: from config"))
+
+	conf.Outputs[0].Network = "carrier-pigeon"
+	casecheck.Error(t, conf.Validate())
+	conf.Outputs[0].Network, conf.Outputs[0].Facility = logx.SyslogNetworkUDP, "nope"
+	casecheck.Error(t, conf.Validate())
+}
+
+func readFrame(t *testing.T, r *bufio.Reader) string {
+	size, err := r.ReadString(' ')
+	casecheck.NoError(t, err)
+	n, err := strconv.Atoi(strings.TrimSpace(size))
+	casecheck.NoError(t, err)
+	b := make([]byte, n)
+	_, err = r.Read(b)
+	casecheck.NoError(t, err)
+	return size + string(b)
+}
+
+func selfSignedCert(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	casecheck.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	casecheck.NoError(t, err)
+	leaf, err := x509.ParseCertificate(der)
+	casecheck.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(leaf)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}, pool
+}
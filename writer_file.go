@@ -0,0 +1,198 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const fileBackupLayout = "20060102T150405.000000000"
+
+// FileOptions rotation settings of FileWriter, zero values disable the limits
+type FileOptions struct {
+	// MaxSize in bytes, the file is rotated before a write would exceed it
+	MaxSize int64
+	// MaxBackups count of rotated files to keep
+	MaxBackups int
+	// MaxAge of rotated files to keep
+	MaxAge time.Duration
+}
+
+// FileWriter appends to a file with size based rotation.
+// Rotated files are named <path>.<UTC timestamp with nanoseconds>.
+type FileWriter struct {
+	mux  sync.Mutex
+	path string
+	opts FileOptions
+	file *os.File
+	size int64
+	last time.Time
+}
+
+// NewFileWriter open or create file for appending
+func NewFileWriter(path string, opts FileOptions) (*FileWriter, error) {
+	v := &FileWriter{path: path, opts: opts}
+	if err := v.open(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (v *FileWriter) open() error {
+	if err := os.MkdirAll(filepath.Dir(v.path), 0755); err != nil {
+		return fmt.Errorf("logx file: %w", err)
+	}
+	file, err := os.OpenFile(v.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("logx file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close() //nolint:errcheck
+		return fmt.Errorf("logx file: %w", err)
+	}
+	v.file, v.size = file, info.Size()
+	return nil
+}
+
+func (v *FileWriter) Write(b []byte) (int, error) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	if v.file == nil {
+		return 0, fmt.Errorf("logx file: %w", os.ErrClosed)
+	}
+	if v.opts.MaxSize > 0 && v.size > 0 && v.size+int64(len(b)) > v.opts.MaxSize {
+		if err := v.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := v.file.Write(b)
+	v.size += int64(n)
+	return n, err
+}
+
+// Rotate rename the current file to a backup and start a new one
+func (v *FileWriter) Rotate() error {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	if v.file == nil {
+		return fmt.Errorf("logx file: %w", os.ErrClosed)
+	}
+	return v.rotate()
+}
+
+// Reopen close and open the file by path again, for files moved by external tools like logrotate
+func (v *FileWriter) Reopen() error {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	if v.file != nil {
+		if err := v.file.Close(); err != nil {
+			return fmt.Errorf("logx file: %w", err)
+		}
+		v.file = nil
+	}
+	return v.open()
+}
+
+func (v *FileWriter) rotate() error {
+	if err := v.file.Close(); err != nil {
+		return fmt.Errorf("logx file rotate: %w", err)
+	}
+	v.file = nil
+
+	// names must grow with every rotation to keep the order of backups
+	ts := time.Now().UTC()
+	if !ts.After(v.last) {
+		ts = v.last.Add(time.Nanosecond)
+	}
+	v.last = ts
+
+	name := v.path + "." + ts.Format(fileBackupLayout)
+	if err := os.Rename(v.path, name); err != nil {
+		return fmt.Errorf("logx file rotate: %w", err)
+	}
+	if err := v.open(); err != nil {
+		return err
+	}
+	return v.cleanup()
+}
+
+func (v *FileWriter) cleanup() error {
+	if v.opts.MaxBackups <= 0 && v.opts.MaxAge <= 0 {
+		return nil
+	}
+	list, err := v.Backups()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for i, name := range list {
+		remove := v.opts.MaxBackups > 0 && i >= v.opts.MaxBackups
+		if !remove && v.opts.MaxAge > 0 {
+			if info, err := os.Stat(name); err == nil && time.Since(info.ModTime()) > v.opts.MaxAge {
+				remove = true
+			}
+		}
+		if remove {
+			if err := os.Remove(name); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if err = errors.Join(errs...); err != nil {
+		return fmt.Errorf("logx file cleanup: %w", err)
+	}
+	return nil
+}
+
+// Backups list of rotated files, newest first
+func (v *FileWriter) Backups() ([]string, error) {
+	list, err := filepath.Glob(v.path + ".*")
+	if err != nil {
+		return nil, fmt.Errorf("logx file: %w", err)
+	}
+	result := list[:0]
+	for _, name := range list {
+		if _, err := time.Parse(fileBackupLayout, strings.TrimPrefix(name, v.path+".")); err != nil {
+			continue
+		}
+		result = append(result, name)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(result)))
+	return result, nil
+}
+
+func (v *FileWriter) Sync() error {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	if v.file == nil {
+		return nil
+	}
+	return v.file.Sync()
+}
+
+func (v *FileWriter) Close() error {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	if v.file == nil {
+		return nil
+	}
+	err := v.file.Close()
+	v.file = nil
+	return err
+}
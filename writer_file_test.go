@@ -0,0 +1,53 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/logx"
+)
+
+func TestUnit_FileWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "app.log")
+
+	w, err := logx.NewFileWriter(path, logx.FileOptions{MaxSize: 20, MaxBackups: 2})
+	casecheck.NoError(t, err)
+
+	for _, line := range []string{"0123456789\n", "abcdefghij\n", "ABCDEFGHIJ\n", "klmnopqrst\n", "KLMNOPQRST\n"} {
+		_, err = w.Write([]byte(line))
+		casecheck.NoError(t, err)
+	}
+	casecheck.NoError(t, w.Sync())
+
+	b, err := os.ReadFile(path)
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, "KLMNOPQRST\n", string(b))
+
+	backups, err := w.Backups()
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, 2, len(backups))
+	b, err = os.ReadFile(backups[0])
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, "klmnopqrst\n", string(b))
+
+	casecheck.NoError(t, w.Rotate())
+	casecheck.NoError(t, w.Close())
+	_, err = w.Write([]byte("closed"))
+	casecheck.Error(t, err)
+
+	list, err := filepath.Glob(path + "*")
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, 3, len(list))
+	for _, name := range list {
+		casecheck.True(t, strings.HasPrefix(filepath.Base(name), "app.log"))
+	}
+}
@@ -0,0 +1,157 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	GELFCompressNone = "none"
+	GELFCompressGzip = "gzip"
+	GELFCompressZlib = "zlib"
+)
+
+const (
+	gelfMaxChunks   = 128
+	gelfChunkHeader = 12
+)
+
+var gelfChunkMagic = []byte{0x1e, 0x0f}
+
+// GELFOptions settings of the GELF transport, zero values use the defaults
+type GELFOptions struct {
+	// Network udp (default), tcp or tls
+	Network string
+	// Address host:port of the Graylog input
+	Address string
+	// Compression of UDP datagrams: gzip (default), zlib or none
+	Compression string
+	// ChunkSize max UDP datagram size, bigger messages are chunked, default 1420
+	ChunkSize int
+	// TLSConfig of the tls network
+	TLSConfig *tls.Config
+	// Net options of the tcp and tls networks, Network, Address, TLSConfig and Framing are set by GELFOptions
+	Net NetOptions
+}
+
+// NewGELFWriter transport for FormatGELF: UDP with compression and chunking,
+// or TCP/TLS with null byte framing based on NetWriter. Every Write is one message.
+func NewGELFWriter(opts GELFOptions) (io.WriteCloser, error) {
+	switch opts.Network {
+	case "", "udp", "udp4", "udp6":
+		return newGELFUDPWriter(opts)
+	case "tcp", "tcp4", "tcp6", "tls":
+		nopts := opts.Net
+		nopts.Network, nopts.Address, nopts.TLSConfig = opts.Network, opts.Address, opts.TLSConfig
+		nopts.Framing = NetFramingNull
+		return NewNetWriter(nopts)
+	default:
+		return nil, fmt.Errorf("logx gelf: unknown network %q", opts.Network)
+	}
+}
+
+type gelfUDPWriter struct {
+	mux         sync.Mutex
+	conn        net.Conn
+	compression string
+	chunkSize   int
+	buf         bytes.Buffer
+}
+
+func newGELFUDPWriter(opts GELFOptions) (*gelfUDPWriter, error) {
+	switch opts.Compression {
+	case "":
+		opts.Compression = GELFCompressGzip
+	case GELFCompressNone, GELFCompressGzip, GELFCompressZlib:
+	default:
+		return nil, fmt.Errorf("logx gelf: unknown compression %q", opts.Compression)
+	}
+	if opts.ChunkSize <= 0 {
+		opts.ChunkSize = 1420
+	}
+	if opts.ChunkSize <= gelfChunkHeader {
+		return nil, fmt.Errorf("logx gelf: chunk size %d is too small", opts.ChunkSize)
+	}
+	network := opts.Network
+	if len(network) == 0 {
+		network = "udp"
+	}
+	conn, err := net.DialTimeout(network, opts.Address, 5*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("logx gelf: %w", err)
+	}
+	return &gelfUDPWriter{conn: conn, compression: opts.Compression, chunkSize: opts.ChunkSize}, nil
+}
+
+func (v *gelfUDPWriter) Write(b []byte) (int, error) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	payload, err := v.compress(bytes.TrimSuffix(b, newLine))
+	if err != nil {
+		return 0, fmt.Errorf("logx gelf: %w", err)
+	}
+	if len(payload) <= v.chunkSize {
+		if _, err = v.conn.Write(payload); err != nil {
+			return 0, fmt.Errorf("logx gelf: %w", err)
+		}
+		return len(b), nil
+	}
+
+	size := v.chunkSize - gelfChunkHeader
+	count := (len(payload) + size - 1) / size
+	if count > gelfMaxChunks {
+		return 0, fmt.Errorf("logx gelf: message needs %d chunks, max %d", count, gelfMaxChunks)
+	}
+	chunk := make([]byte, 0, v.chunkSize)
+	id := rand.Uint64() //nolint:gosec
+	for i := 0; i < count; i++ {
+		chunk = append(chunk[:0], gelfChunkMagic...)
+		for shift := 56; shift >= 0; shift -= 8 {
+			chunk = append(chunk, byte(id>>shift))
+		}
+		chunk = append(chunk, byte(i), byte(count))
+		chunk = append(chunk, payload[i*size:min((i+1)*size, len(payload))]...)
+		if _, err = v.conn.Write(chunk); err != nil {
+			return 0, fmt.Errorf("logx gelf: %w", err)
+		}
+	}
+	return len(b), nil
+}
+
+func (v *gelfUDPWriter) compress(b []byte) ([]byte, error) {
+	var zw io.WriteCloser
+	v.buf.Reset()
+	switch v.compression {
+	case GELFCompressGzip:
+		zw = gzip.NewWriter(&v.buf)
+	case GELFCompressZlib:
+		zw = zlib.NewWriter(&v.buf)
+	default:
+		return b, nil
+	}
+	if _, err := zw.Write(b); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return v.buf.Bytes(), nil
+}
+
+func (v *gelfUDPWriter) Close() error {
+	return v.conn.Close()
+}
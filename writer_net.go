@@ -0,0 +1,428 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// netMaxDatagram max payload of an UDP datagram over IPv4
+const netMaxDatagram = 65507
+
+const (
+	NetFramingNewline = "newline"
+	NetFramingLength  = "length"
+	NetFramingNull    = "null"
+	NetFramingOctet   = "octet"
+	NetFramingNone    = "none"
+)
+
+// NetOptions settings of NetWriter, zero values use the defaults
+type NetOptions struct {
+	// Network tcp, udp, unix, unixgram or tls
+	Network string
+	// Address host:port or socket path
+	Address string
+	// TLSConfig of the tls network
+	TLSConfig *tls.Config
+	// Framing newline (default), length - 4 bytes big-endian size before every record,
+	// null - zero byte after every record instead of the new line (GELF TCP),
+	// octet - decimal size and a space before every record (syslog RFC 6587),
+	// or none - records are sent as is, for datagram networks
+	Framing string
+	// BufferSize max count of records waiting for delivery, default 1024
+	BufferSize int
+	// DialTimeout and WriteTimeout, default 5s
+	DialTimeout  time.Duration
+	WriteTimeout time.Duration
+	// MinBackoff and MaxBackoff of reconnects, default 100ms and 30s
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// NetState connection state of NetWriter
+type NetState uint32
+
+const (
+	NetStateConnecting NetState = iota
+	NetStateConnected
+	NetStateDisconnected
+	NetStateClosed
+)
+
+func (v NetState) String() string {
+	switch v {
+	case NetStateConnecting:
+		return "connecting"
+	case NetStateConnected:
+		return "connected"
+	case NetStateDisconnected:
+		return "disconnected"
+	case NetStateClosed:
+		return "closed"
+	default:
+		return fmt.Sprintf("NetState(%d)", uint32(v))
+	}
+}
+
+// NetStats counters of NetWriter
+type NetStats struct {
+	State NetState
+	// Buffered records waiting for delivery
+	Buffered int
+	// Sent records
+	Sent uint64
+	// Dropped records because of the full buffer, Close while disconnected,
+	// or records rejected by the network, e.g. larger than an UDP datagram
+	Dropped uint64
+	// Reconnects after a failed dial or write
+	Reconnects uint64
+}
+
+// NetWriter sends records to a collector (Fluent Bit, Vector) in the background.
+// Write never blocks on the network: records are buffered while the connection
+// is re-established with exponential backoff and jitter, and dropped when the buffer is full.
+type NetWriter struct {
+	opts NetOptions
+
+	mux     sync.Mutex
+	cond    *sync.Cond
+	queue   [][]byte
+	pending int
+	closed  bool
+
+	state      atomic.Uint32
+	sent       atomic.Uint64
+	dropped    atomic.Uint64
+	reconnects atomic.Uint64
+
+	quit    chan struct{}
+	stopped chan struct{}
+	// drainUntil limits delivery of buffered records after Close, set before quit is closed
+	drainUntil time.Time
+}
+
+// NewNetWriter validate options and start delivery, the collector may be unavailable yet
+func NewNetWriter(opts NetOptions) (*NetWriter, error) {
+	switch opts.Network {
+	case "tcp", "tcp4", "tcp6", "udp", "udp4", "udp6", "unix", "unixgram", "tls":
+	default:
+		return nil, fmt.Errorf("logx net: unknown network %q", opts.Network)
+	}
+	switch opts.Framing {
+	case "":
+		opts.Framing = NetFramingNewline
+	case NetFramingNewline, NetFramingLength, NetFramingNull, NetFramingOctet, NetFramingNone:
+	default:
+		return nil, fmt.Errorf("logx net: unknown framing %q", opts.Framing)
+	}
+	if len(opts.Address) == 0 {
+		return nil, fmt.Errorf("logx net: empty address")
+	}
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = 1024
+	}
+	if opts.DialTimeout <= 0 {
+		opts.DialTimeout = 5 * time.Second
+	}
+	if opts.WriteTimeout <= 0 {
+		opts.WriteTimeout = 5 * time.Second
+	}
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = 100 * time.Millisecond
+	}
+	if opts.MaxBackoff < opts.MinBackoff {
+		opts.MaxBackoff = max(30*time.Second, opts.MinBackoff)
+	}
+
+	v := &NetWriter{
+		opts:    opts,
+		quit:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	v.cond = sync.NewCond(&v.mux)
+	go v.run()
+	return v, nil
+}
+
+// Write copy b to the buffer, b is one record
+func (v *NetWriter) Write(b []byte) (int, error) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	if v.closed {
+		return 0, fmt.Errorf("logx net: %w", net.ErrClosed)
+	}
+	frame := v.frame(b)
+	if v.pending >= v.opts.BufferSize || v.oversized(frame) {
+		v.dropped.Add(1)
+		return len(b), nil
+	}
+	v.queue = append(v.queue, frame)
+	v.pending++
+	v.cond.Signal()
+	return len(b), nil
+}
+
+// oversized record which never fits into an UDP datagram
+func (v *NetWriter) oversized(b []byte) bool {
+	switch v.opts.Network {
+	case "udp", "udp4", "udp6":
+		return len(b) > netMaxDatagram
+	default:
+		return false
+	}
+}
+
+func (v *NetWriter) frame(b []byte) []byte {
+	switch v.opts.Framing {
+	case NetFramingLength:
+		b = bytes.TrimSuffix(b, newLine)
+		frame := make([]byte, 4, len(b)+4)
+		binary.BigEndian.PutUint32(frame, uint32(len(b))) //nolint:gosec
+		return append(frame, b...)
+	case NetFramingNull:
+		b = bytes.TrimSuffix(b, newLine)
+		frame := make([]byte, 0, len(b)+1)
+		frame = append(frame, b...)
+		return append(frame, 0)
+	case NetFramingOctet:
+		b = bytes.TrimSuffix(b, newLine)
+		frame := make([]byte, 0, len(b)+8)
+		frame = strconv.AppendInt(frame, int64(len(b)), 10)
+		frame = append(frame, ' ')
+		return append(frame, b...)
+	case NetFramingNone:
+		return append(make([]byte, 0, len(b)), b...)
+	}
+	frame := make([]byte, 0, len(b)+1)
+	frame = append(frame, b...)
+	if !bytes.HasSuffix(frame, newLine) {
+		frame = append(frame, '\n')
+	}
+	return frame
+}
+
+// State of the connection
+func (v *NetWriter) State() NetState {
+	return NetState(v.state.Load())
+}
+
+// Stats current counters
+func (v *NetWriter) Stats() NetStats {
+	v.mux.Lock()
+	buffered := v.pending
+	v.mux.Unlock()
+	return NetStats{
+		State:      v.State(),
+		Buffered:   buffered,
+		Sent:       v.sent.Load(),
+		Dropped:    v.dropped.Load(),
+		Reconnects: v.reconnects.Load(),
+	}
+}
+
+// Flush wait until buffered records are sent, fails at once if the collector is disconnected
+func (v *NetWriter) Flush() error {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	for v.pending > 0 {
+		if state := v.State(); state == NetStateDisconnected || state == NetStateClosed {
+			return fmt.Errorf("logx net: %d records are not sent, %s", v.pending, state)
+		}
+		v.cond.Wait()
+	}
+	return nil
+}
+
+// Close stop delivery. Buffered records are sent if the collector is connected,
+// records not sent within WriteTimeout and all records while disconnected are dropped.
+func (v *NetWriter) Close() error {
+	v.mux.Lock()
+	if v.closed {
+		v.mux.Unlock()
+		return nil
+	}
+	v.closed = true
+	v.cond.Broadcast()
+	v.mux.Unlock()
+
+	v.drainUntil = time.Now().Add(v.opts.WriteTimeout)
+	close(v.quit)
+	<-v.stopped
+	return nil
+}
+
+func (v *NetWriter) setState(s NetState) {
+	v.state.Store(uint32(s))
+	v.mux.Lock()
+	v.cond.Broadcast()
+	v.mux.Unlock()
+}
+
+// next record to send, false after Close when the buffer is empty
+func (v *NetWriter) next() ([]byte, bool) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	for len(v.queue) == 0 && !v.closed {
+		v.cond.Wait()
+	}
+	if len(v.queue) == 0 {
+		return nil, false
+	}
+	b := v.queue[0]
+	v.queue[0] = nil
+	v.queue = v.queue[1:]
+	return b, true
+}
+
+// done count the record taken by next as sent or dropped
+func (v *NetWriter) done(sent bool) {
+	if sent {
+		v.sent.Add(1)
+	} else {
+		v.dropped.Add(1)
+	}
+	v.mux.Lock()
+	v.pending--
+	v.cond.Broadcast()
+	v.mux.Unlock()
+}
+
+func (v *NetWriter) dropAll() {
+	v.mux.Lock()
+	v.dropped.Add(uint64(v.pending)) //nolint:gosec
+	v.queue, v.pending = nil, 0
+	v.cond.Broadcast()
+	v.mux.Unlock()
+}
+
+func (v *NetWriter) run() {
+	var conn net.Conn
+	defer func() {
+		if conn != nil {
+			conn.Close() //nolint:errcheck
+		}
+		v.setState(NetStateClosed)
+		close(v.stopped)
+	}()
+
+	backoff := v.opts.MinBackoff
+	for {
+		b, ok := v.next()
+		if !ok {
+			return
+		}
+		sent := true
+		for {
+			if conn == nil {
+				if v.closing() {
+					v.dropAll()
+					return
+				}
+				c, err := v.dial()
+				if err != nil {
+					v.setState(NetStateDisconnected)
+					if !v.retry(&backoff) {
+						v.dropAll()
+						return
+					}
+					continue
+				}
+				conn = c
+				v.setState(NetStateConnected)
+			}
+			if err := v.write(conn, b); err != nil {
+				if netRejected(err) {
+					// the record itself is rejected, the connection is fine
+					sent = false
+					break
+				}
+				conn.Close() //nolint:errcheck
+				conn = nil
+				v.setState(NetStateDisconnected)
+				if !v.retry(&backoff) {
+					v.dropAll()
+					return
+				}
+				continue
+			}
+			backoff = v.opts.MinBackoff
+			break
+		}
+		v.done(sent)
+	}
+}
+
+// netRejected errors of the record which fail again on any connection, e.g. too large datagram
+func netRejected(err error) bool {
+	return errors.Is(err, syscall.EMSGSIZE)
+}
+
+// retry wait backoff before the next attempt and double it, false if the writer is closed
+func (v *NetWriter) retry(backoff *time.Duration) bool {
+	if !v.sleep(*backoff) {
+		return false
+	}
+	*backoff = min(*backoff*2, v.opts.MaxBackoff)
+	v.reconnects.Add(1)
+	return true
+}
+
+func (v *NetWriter) closing() bool {
+	select {
+	case <-v.quit:
+		return true
+	default:
+		return false
+	}
+}
+
+func (v *NetWriter) dial() (net.Conn, error) {
+	if v.opts.Network == "tls" {
+		d := &net.Dialer{Timeout: v.opts.DialTimeout}
+		return tls.DialWithDialer(d, "tcp", v.opts.Address, v.opts.TLSConfig)
+	}
+	return net.DialTimeout(v.opts.Network, v.opts.Address, v.opts.DialTimeout)
+}
+
+func (v *NetWriter) write(conn net.Conn, b []byte) error {
+	deadline := time.Now().Add(v.opts.WriteTimeout)
+	if v.closing() && v.drainUntil.Before(deadline) {
+		deadline = v.drainUntil
+	}
+	if err := conn.SetWriteDeadline(deadline); err != nil {
+		return err
+	}
+	_, err := conn.Write(b)
+	return err
+}
+
+// sleep backoff with jitter from a half to the full duration, false if the writer is closed
+func (v *NetWriter) sleep(backoff time.Duration) bool {
+	d := backoff/2 + rand.N(backoff/2+1) //nolint:gosec
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-v.quit:
+		return false
+	}
+}
@@ -0,0 +1,214 @@
+/*
+ *  Copyright (c) 2024-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package logx_test
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/binary"
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/logx"
+)
+
+func TestUnit_NetWriterReconnect(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	casecheck.NoError(t, err)
+	addr := ln.Addr().String()
+
+	w, err := logx.NewNetWriter(logx.NetOptions{
+		Network:    "tcp",
+		Address:    addr,
+		MinBackoff: 10 * time.Millisecond,
+		MaxBackoff: 50 * time.Millisecond,
+	})
+	casecheck.NoError(t, err)
+	defer w.Close() //nolint:errcheck
+
+	l := logx.New(logx.WithOutput(w), logx.WithFormatter(logx.NewFormatString()), logx.WithLevel(logx.LevelInfo))
+	l.Info("first")
+	casecheck.NoError(t, l.Sync())
+	casecheck.Equal(t, logx.NetStateConnected, w.State())
+
+	conn, err := ln.Accept()
+	casecheck.NoError(t, err)
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	casecheck.NoError(t, err)
+	casecheck.Contains(t, line, "\"msg\"=\"first\"")
+
+	casecheck.NoError(t, conn.Close())
+	casecheck.NoError(t, ln.Close())
+	// the closed connection is noticed by a failed write
+	for i := 0; i < 100 && w.State() == logx.NetStateConnected; i++ {
+		l.Info("probe")
+		time.Sleep(10 * time.Millisecond)
+	}
+	casecheck.Equal(t, logx.NetStateDisconnected, w.State())
+	casecheck.Error(t, w.Flush())
+
+	l.Info("buffered 1")
+	l.Info("buffered 2")
+	casecheck.True(t, w.Stats().Buffered >= 2)
+
+	ln, err = net.Listen("tcp", addr)
+	casecheck.NoError(t, err)
+	defer ln.Close() //nolint:errcheck
+	conn, err = ln.Accept()
+	casecheck.NoError(t, err)
+	defer conn.Close() //nolint:errcheck
+
+	r := bufio.NewReader(conn)
+	var last []string
+	for len(last) == 0 || last[len(last)-1] != "buffered 2" {
+		casecheck.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		line, err = r.ReadString('\n')
+		casecheck.NoError(t, err)
+		for _, msg := range []string{"probe", "buffered 1", "buffered 2"} {
+			if strings.Contains(line, "\"msg\"=\""+msg+"\"") {
+				last = append(last, msg)
+			}
+		}
+	}
+	casecheck.Equal(t, "buffered 1", last[len(last)-2])
+
+	stats := w.Stats()
+	casecheck.Equal(t, 0, stats.Buffered)
+	casecheck.True(t, stats.Reconnects > 0)
+	casecheck.Equal(t, uint64(0), stats.Dropped)
+}
+
+func TestUnit_NetWriterDrop(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	casecheck.NoError(t, err)
+	addr := ln.Addr().String()
+	casecheck.NoError(t, ln.Close())
+
+	w, err := logx.NewNetWriter(logx.NetOptions{Network: "tcp", Address: addr, BufferSize: 2})
+	casecheck.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		_, err = w.Write([]byte("record\n"))
+		casecheck.NoError(t, err)
+	}
+	casecheck.Equal(t, uint64(3), w.Stats().Dropped)
+	casecheck.NoError(t, w.Close())
+
+	stats := w.Stats()
+	casecheck.Equal(t, logx.NetStateClosed, stats.State)
+	casecheck.Equal(t, uint64(5), stats.Dropped)
+	_, err = w.Write([]byte("closed"))
+	casecheck.Error(t, err)
+
+	_, err = logx.NewNetWriter(logx.NetOptions{Network: "sctp", Address: addr})
+	casecheck.Error(t, err)
+}
+
+func TestUnit_NetWriterCloseBounded(t *testing.T) {
+	// the collector accepts connections and never reads
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	casecheck.NoError(t, err)
+	defer ln.Close() //nolint:errcheck
+
+	w, err := logx.NewNetWriter(logx.NetOptions{
+		Network:      "tcp",
+		Address:      ln.Addr().String(),
+		BufferSize:   64,
+		WriteTimeout: 100 * time.Millisecond,
+	})
+	casecheck.NoError(t, err)
+
+	record := []byte(strings.Repeat("x", 1<<20) + "\n")
+	for i := 0; i < 64; i++ {
+		_, err = w.Write(record)
+		casecheck.NoError(t, err)
+	}
+
+	start := time.Now()
+	casecheck.NoError(t, w.Close())
+	casecheck.True(t, time.Since(start) < 2*time.Second)
+
+	stats := w.Stats()
+	casecheck.Equal(t, logx.NetStateClosed, stats.State)
+	casecheck.Equal(t, 0, stats.Buffered)
+	casecheck.True(t, stats.Dropped > 0)
+}
+
+func TestUnit_NetWriterRejectedRecords(t *testing.T) {
+	udp, err := net.ListenPacket("udp", "127.0.0.1:0")
+	casecheck.NoError(t, err)
+	defer udp.Close() //nolint:errcheck
+	unixgram, err := net.ListenPacket("unixgram", filepath.Join(t.TempDir(), "net.sock"))
+	casecheck.NoError(t, err)
+	defer unixgram.Close() //nolint:errcheck
+
+	for _, conn := range []net.PacketConn{udp, unixgram} {
+		t.Run(conn.LocalAddr().Network(), func(t *testing.T) {
+			w, err := logx.NewNetWriter(logx.NetOptions{
+				Network:    conn.LocalAddr().Network(),
+				Address:    conn.LocalAddr().String(),
+				MinBackoff: 10 * time.Millisecond,
+			})
+			casecheck.NoError(t, err)
+			defer w.Close() //nolint:errcheck
+
+			// larger than an UDP datagram and than the unix socket buffer
+			_, err = w.Write([]byte(strings.Repeat("x", 4<<20)))
+			casecheck.NoError(t, err)
+			_, err = w.Write([]byte("small"))
+			casecheck.NoError(t, err)
+
+			buf := make([]byte, 1024)
+			casecheck.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+			n, _, err := conn.ReadFrom(buf)
+			casecheck.NoError(t, err)
+			casecheck.Equal(t, "small\n", string(buf[:n]))
+
+			casecheck.NoError(t, w.Flush())
+			stats := w.Stats()
+			casecheck.Equal(t, uint64(1), stats.Sent)
+			casecheck.Equal(t, uint64(1), stats.Dropped)
+			casecheck.Equal(t, uint64(0), stats.Reconnects)
+		})
+	}
+}
+
+func TestUnit_NetWriterTLSLength(t *testing.T) {
+	cert, pool := selfSignedCert(t)
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12})
+	casecheck.NoError(t, err)
+	defer ln.Close() //nolint:errcheck
+
+	w, err := logx.NewNetWriter(logx.NetOptions{
+		Network:   "tls",
+		Address:   ln.Addr().String(),
+		TLSConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+		Framing:   logx.NetFramingLength,
+	})
+	casecheck.NoError(t, err)
+	defer w.Close() //nolint:errcheck
+	_, err = w.Write([]byte("{\"msg\":\"a\"}\n"))
+	casecheck.NoError(t, err)
+
+	conn, err := ln.Accept()
+	casecheck.NoError(t, err)
+	defer conn.Close() //nolint:errcheck
+	casecheck.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	var size uint32
+	casecheck.NoError(t, binary.Read(conn, binary.BigEndian, &size))
+	b := make([]byte, size)
+	_, err = io.ReadFull(conn, b)
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, "{\"msg\":\"a\"}", string(b))
+	casecheck.NoError(t, w.Flush())
+	casecheck.Equal(t, uint64(1), w.Stats().Sent)
+}